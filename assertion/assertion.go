@@ -0,0 +1,421 @@
+// Package assertion will check response by declarative assertions like status in range,
+// header matches regex, JSONPath equals or body matches schema and return pass or fail of each of them
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	jsonpath "mcp-api-tester/jsonPath"
+	netclient "mcp-api-tester/net/client"
+	"mcp-api-tester/validator"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// sources of assertion
+const (
+	SourceStatus       = "status"
+	SourceHeader       = "header"
+	SourceJSONPath     = "jsonPath"
+	SourceBody         = "body"
+	SourceResponseTime = "responseTime"
+)
+
+// operators of assertion
+const (
+	OpEquals        = "equals"
+	OpNotEquals     = "notEquals"
+	OpIn            = "in"
+	OpRange         = "range"
+	OpExists        = "exists"
+	OpNotExists     = "notExists"
+	OpContains      = "contains"
+	OpMatches       = "matches"
+	OpType          = "type"
+	OpLength        = "length"
+	OpMatchesSchema = "matchesSchema"
+	OpBelow         = "below"
+)
+
+// operators is what each source support
+var operators = map[string][]string{
+	SourceStatus:       {OpEquals, OpNotEquals, OpIn, OpRange},
+	SourceHeader:       {OpExists, OpNotExists, OpEquals, OpNotEquals, OpContains, OpMatches},
+	SourceJSONPath:     {OpExists, OpNotExists, OpEquals, OpNotEquals, OpContains, OpMatches, OpType, OpLength},
+	SourceBody:         {OpContains, OpMatches, OpMatchesSchema},
+	SourceResponseTime: {OpBelow},
+}
+
+// Assertion is one check of response
+type Assertion struct {
+	Name   string `json:"name,omitempty" yaml:"name,omitempty" jsonschema:"description=Name shown in result\\, generated from source and op if empty"`
+	Source string `json:"source" yaml:"source" jsonschema:"required,description=What to check,enum=status,enum=header,enum=jsonPath,enum=body,enum=responseTime"`
+	Path   string `json:"path,omitempty" yaml:"path,omitempty" jsonschema:"description=Header name like Content-Type for header or JSONPath like $.data[0].id for jsonPath"`
+	Op     string `json:"op" yaml:"op" jsonschema:"required,description=status: equals/notEquals/in/range; header: exists/notExists/equals/notEquals/contains/matches; jsonPath: exists/notExists/equals/notEquals/contains/matches/type/length; body: contains/matches/matchesSchema; responseTime: below"`
+	Value  any    `json:"value,omitempty" yaml:"value,omitempty" jsonschema:"description=Expected value: status code\\, list of codes for in\\, 2xx or 200-299 for range\\, regex for matches\\, json type name like string or array for type\\, number or {min\\,max} for length\\, milliseconds for below"`
+}
+
+// Result is the outcome of one assertion
+type Result struct {
+	Name     string `json:"name" yaml:"name"`
+	Passed   bool   `json:"passed" yaml:"passed"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+	Expected any    `json:"expected,omitempty" yaml:"expected,omitempty"` // only filled when assertion failed
+	Actual   any    `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// Validate check source support op and path and value are given when needed
+func (a Assertion) Validate() error {
+	supported, ok := operators[a.Source]
+	if !ok {
+		return fmt.Errorf("Source %q of assertion is not supported, please use one of status, header, jsonPath, body or responseTime", a.Source)
+	}
+
+	if !slices.Contains(supported, a.Op) {
+		return fmt.Errorf("Op %q is not supported by %s assertion, please use one of %s", a.Op, a.Source, strings.Join(supported, ", "))
+	}
+
+	if (a.Source == SourceHeader || a.Source == SourceJSONPath) && a.Path == "" {
+		return fmt.Errorf("Path is required by %s assertion", a.Source)
+	}
+
+	if a.Source == SourceJSONPath {
+		if _, err := jsonpath.Parse(a.Path); err != nil {
+			return err
+		}
+	}
+
+	if a.Op == OpMatches {
+		pattern, ok := a.Value.(string)
+		if !ok {
+			return fmt.Errorf("Value of matches should be regex string")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("Regex %q is invalid, error: %w", pattern, err)
+		}
+	}
+
+	if a.Value == nil && !slices.Contains([]string{OpExists, OpNotExists, OpMatchesSchema}, a.Op) {
+		return fmt.Errorf("Value is required by %s of %s assertion", a.Op, a.Source)
+	}
+
+	return nil
+}
+
+// Validate check every assertion
+func Validate(assertions []Assertion) error {
+	for i, a := range assertions {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("Assertion %d is invalid, error: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// NeedSchema tell whether operation in OpenAPI file is needed to evaluate assertions
+func NeedSchema(assertions []Assertion) bool {
+	return slices.ContainsFunc(assertions, func(a Assertion) bool {
+		return a.Op == OpMatchesSchema
+	})
+}
+
+// Passed tell whether every result passed
+func Passed(results []Result) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate check response by every assertion, operation is only used by matchesSchema and can be nil
+func Evaluate(assertions []Assertion, response *netclient.AIResponse, operation *v3high.Operation) []Result {
+	results := make([]Result, 0, len(assertions))
+	for _, a := range assertions {
+		results = append(results, a.Evaluate(response, operation))
+	}
+	return results
+}
+
+// Evaluate check response by assertion
+func (a Assertion) Evaluate(response *netclient.AIResponse, operation *v3high.Operation) Result {
+	result := Result{Name: a.name()}
+
+	if err := a.Validate(); err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	var actual any
+	var found bool
+
+	switch a.Source {
+	case SourceStatus:
+		actual, found = response.StatusCode, true
+	case SourceHeader:
+		actual, found = header(response, a.Path)
+	case SourceJSONPath:
+		actual, found, _ = jsonpath.Get(response.Body, a.Path)
+	case SourceBody:
+		actual, found = string(response.RawBody), true
+	case SourceResponseTime:
+		actual, found = response.ElapsedMs, true
+	}
+
+	if a.Op == OpMatchesSchema {
+		return a.matchesSchema(result, response, operation)
+	}
+
+	passed, message := a.compare(actual, found)
+
+	result.Passed = passed
+	if !passed {
+		result.Message = message
+		result.Expected = a.Value
+		if found && a.Source != SourceBody {
+			result.Actual = actual
+		}
+	}
+
+	return result
+}
+
+// compare apply op on actual value, message tell why it failed
+func (a Assertion) compare(actual any, found bool) (bool, string) {
+	switch a.Op {
+	case OpExists:
+		return found, fmt.Sprintf("%s is not found", a.Path)
+	case OpNotExists:
+		return !found, fmt.Sprintf("%s exists", a.Path)
+	}
+
+	if !found {
+		return false, fmt.Sprintf("%s is not found in response", a.Path)
+	}
+
+	switch a.Op {
+	case OpEquals:
+		return equalJSON(actual, a.Value), fmt.Sprintf("got %s", stringify(actual))
+	case OpNotEquals:
+		return !equalJSON(actual, a.Value), fmt.Sprintf("got %s", stringify(actual))
+	case OpIn:
+		values, ok := normalize(a.Value).([]any)
+		if !ok {
+			return false, "Value of in should be list"
+		}
+		return slices.ContainsFunc(values, func(v any) bool { return equalJSON(actual, v) }), fmt.Sprintf("got %s", stringify(actual))
+	case OpRange:
+		low, high, err := statusRange(a.Value)
+		if err != nil {
+			return false, err.Error()
+		}
+		status := actual.(int)
+		return status >= low && status <= high, fmt.Sprintf("got %d", status)
+	case OpContains:
+		return contains(actual, a.Value), fmt.Sprintf("%s doesn't contain %s", describe(a.Source, actual), stringify(a.Value))
+	case OpMatches:
+		text, ok := actual.(string)
+		if !ok {
+			text = stringify(actual)
+		}
+		return regexp.MustCompile(a.Value.(string)).MatchString(text), fmt.Sprintf("%s doesn't match %s", describe(a.Source, actual), a.Value)
+	case OpType:
+		expected := fmt.Sprint(a.Value)
+		actualType := validator.TypeOf(actual)
+		// integer is also a number
+		passed := actualType == expected || (expected == "number" && actualType == "integer")
+		return passed, fmt.Sprintf("got %s", actualType)
+	case OpLength:
+		return checkLength(actual, a.Value)
+	case OpBelow:
+		limit, ok := toFloat(a.Value)
+		if !ok {
+			return false, "Value of below should be milliseconds"
+		}
+		return float64(actual.(int64)) < limit, fmt.Sprintf("took %dms", actual)
+	}
+
+	return false, fmt.Sprintf("Op %q is not supported", a.Op)
+}
+
+// matchesSchema validate status, content type and body against operation
+func (a Assertion) matchesSchema(result Result, response *netclient.AIResponse, operation *v3high.Operation) Result {
+	if operation == nil {
+		result.Message = "No operation in OpenAPI file matches the request"
+		return result
+	}
+
+	validation := validator.ValidateResponse(operation, response.StatusCode, response.Headers["Content-Type"], response.RawBody)
+	result.Passed = validation.Valid
+
+	if !validation.Valid {
+		messages := make([]string, 0, len(validation.Violations))
+		for _, violation := range validation.Violations {
+			messages = append(messages, violation.String())
+		}
+		result.Message = strings.Join(messages, "; ")
+	}
+
+	return result
+}
+
+// name is like "jsonPath $.id equals 1"
+func (a Assertion) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+
+	parts := []string{a.Source}
+	if a.Path != "" {
+		parts = append(parts, a.Path)
+	}
+	parts = append(parts, a.Op)
+	if a.Value != nil {
+		parts = append(parts, stringify(a.Value))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// header find header case-insensitively
+func header(response *netclient.AIResponse, name string) (any, bool) {
+	for key, value := range response.Headers {
+		if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// statusRange parse 2xx, 200-299 or [200, 299]
+func statusRange(value any) (int, int, error) {
+	if bounds, ok := normalize(value).([]any); ok && len(bounds) == 2 {
+		low, lowOK := toFloat(bounds[0])
+		high, highOK := toFloat(bounds[1])
+		if lowOK && highOK {
+			return int(low), int(high), nil
+		}
+	}
+
+	text := strings.ToLower(strings.TrimSpace(fmt.Sprint(value)))
+
+	if len(text) == 3 && strings.HasSuffix(text, "xx") && text[0] >= '1' && text[0] <= '5' {
+		low := int(text[0]-'0') * 100
+		return low, low + 99, nil
+	}
+
+	if lowText, highText, ok := strings.Cut(text, "-"); ok {
+		low, lowErr := strconv.Atoi(strings.TrimSpace(lowText))
+		high, highErr := strconv.Atoi(strings.TrimSpace(highText))
+		if lowErr == nil && highErr == nil {
+			return low, high, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("Range %v should be like 2xx, 200-299 or [200, 299]", value)
+}
+
+// contains check substring of string, element of array or key of object
+func contains(actual any, expected any) bool {
+	switch v := actual.(type) {
+	case string:
+		return strings.Contains(v, fmt.Sprint(expected))
+	case []any:
+		return slices.ContainsFunc(v, func(item any) bool { return equalJSON(item, expected) })
+	case map[string]any:
+		_, ok := v[fmt.Sprint(expected)]
+		return ok
+	}
+	return false
+}
+
+// checkLength compare length of string, array or object with number or {min, max}
+func checkLength(actual any, expected any) (bool, string) {
+	var length int
+	switch v := actual.(type) {
+	case string:
+		length = len([]rune(v))
+	case []any:
+		length = len(v)
+	case map[string]any:
+		length = len(v)
+	default:
+		return false, fmt.Sprintf("%s has no length", validator.TypeOf(actual))
+	}
+
+	message := fmt.Sprintf("got length %d", length)
+
+	if exact, ok := toFloat(expected); ok {
+		return float64(length) == exact, message
+	}
+
+	bounds, ok := normalize(expected).(map[string]any)
+	if !ok {
+		return false, "Value of length should be number or {min, max}"
+	}
+
+	if low, ok := toFloat(bounds["min"]); ok && float64(length) < low {
+		return false, message
+	}
+	if high, ok := toFloat(bounds["max"]); ok && float64(length) > high {
+		return false, message
+	}
+
+	return true, message
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := normalize(value).(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// describe avoid printing whole body in message
+func describe(source string, actual any) string {
+	if source == SourceBody {
+		return "body"
+	}
+	return stringify(actual)
+}
+
+// equalJSON compare values after json round trip so 1 and 1.0 are equal
+func equalJSON(a any, b any) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(value any) any {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(valueBytes, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func stringify(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(valueBytes)
+}
@@ -0,0 +1,127 @@
+package assertion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+)
+
+func newResponse() *netclient.AIResponse {
+	rawBody := []byte(`{"id":7,"name":"Alice","tags":["a","b"],"profile":{"age":30}}`)
+	return &netclient.AIResponse{
+		StatusCode: 201,
+		Headers:    map[string]string{"Content-Type": "application/json; charset=utf-8", "Location": "/users/7"},
+		Body:       netclient.DecodeBody(rawBody),
+		RawBody:    rawBody,
+		ElapsedMs:  120,
+	}
+}
+
+func Test_Evaluate(t *testing.T) {
+	tests := []struct {
+		assertion Assertion
+		passed    bool
+	}{
+		{Assertion{Source: SourceStatus, Op: OpEquals, Value: 201}, true},
+		{Assertion{Source: SourceStatus, Op: OpIn, Value: []int{200, 204}}, false},
+		{Assertion{Source: SourceStatus, Op: OpRange, Value: "2xx"}, true},
+		{Assertion{Source: SourceStatus, Op: OpRange, Value: "300-399"}, false},
+		{Assertion{Source: SourceStatus, Op: OpRange, Value: []any{200, 201}}, true},
+		{Assertion{Source: SourceHeader, Path: "content-type", Op: OpMatches, Value: `^application/json`}, true},
+		{Assertion{Source: SourceHeader, Path: "X-Request-Id", Op: OpExists}, false},
+		{Assertion{Source: SourceHeader, Path: "Location", Op: OpEquals, Value: "/users/7"}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.id", Op: OpEquals, Value: 7}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.id", Op: OpType, Value: "number"}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.name", Op: OpType, Value: "integer"}, false},
+		{Assertion{Source: SourceJSONPath, Path: "$.tags", Op: OpContains, Value: "b"}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.tags", Op: OpLength, Value: 2}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.tags", Op: OpLength, Value: map[string]any{"min": 3}}, false},
+		{Assertion{Source: SourceJSONPath, Path: "$.profile", Op: OpContains, Value: "age"}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.missing", Op: OpNotExists}, true},
+		{Assertion{Source: SourceJSONPath, Path: "$.missing", Op: OpEquals, Value: 1}, false},
+		{Assertion{Source: SourceBody, Op: OpContains, Value: "Alice"}, true},
+		{Assertion{Source: SourceResponseTime, Op: OpBelow, Value: 100}, false},
+		{Assertion{Source: SourceResponseTime, Op: OpBelow, Value: 500}, true},
+	}
+
+	response := newResponse()
+
+	for _, test := range tests {
+		result := test.assertion.Evaluate(response, nil)
+		if result.Passed != test.passed {
+			t.Errorf("%s: expected passed %v, got %+v", result.Name, test.passed, result)
+		}
+		if !result.Passed && result.Message == "" {
+			t.Errorf("%s: failed assertion should have message", result.Name)
+		}
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	invalid := []Assertion{
+		{Source: "cookie", Op: OpEquals, Value: 1},
+		{Source: SourceStatus, Op: OpMatches, Value: "2.."},
+		{Source: SourceJSONPath, Op: OpExists},
+		{Source: SourceHeader, Path: "Location", Op: OpMatches, Value: "("},
+		{Source: SourceStatus, Op: OpEquals},
+	}
+
+	for _, a := range invalid {
+		if err := a.Validate(); err == nil {
+			t.Errorf("assertion %+v should be invalid", a)
+		}
+	}
+}
+
+func Test_Evaluate_MatchesSchema(t *testing.T) {
+	spec := `
+openapi: 3.0.0
+info:
+  title: users
+  version: "1"
+paths:
+  /users:
+    post:
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, email]
+                properties:
+                  id:
+                    type: integer
+                  email:
+                    type: string
+`
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	document, err := openapi.ReadFromPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matched, err := document.FindOperation("POST", "/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := Assertion{Source: SourceBody, Op: OpMatchesSchema}
+
+	result := schema.Evaluate(newResponse(), matched.Operation)
+	if result.Passed || result.Message == "" {
+		t.Errorf("body without email should not match schema, got %+v", result)
+	}
+
+	if result := schema.Evaluate(newResponse(), nil); result.Passed {
+		t.Errorf("matchesSchema without operation should fail")
+	}
+}
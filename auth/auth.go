@@ -0,0 +1,257 @@
+// Package auth will attach credentials to request by security requirements of operation,
+// credentials are configured per security scheme like bearerAuth or apiKeyAuth
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	"net/http"
+	"strings"
+)
+
+// Credential is the secret of one security scheme, which field is used depend on type of scheme
+type Credential struct {
+	Token    string `json:"token,omitempty" yaml:"token,omitempty"`       // used by http bearer, oauth2 and openIdConnect
+	Username string `json:"username,omitempty" yaml:"username,omitempty"` // used by http basic
+	Password string `json:"password,omitempty" yaml:"password,omitempty"` // used by http basic
+	Value    string `json:"value,omitempty" yaml:"value,omitempty"`       // used by apiKey and other http schemes
+
+	// used by oauth2 to request token when token is not given, password flow also use username and password
+	ClientID         string   `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	ClientSecret     string   `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	Scopes           []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`                     // scopes required by operation will be used if empty
+	Flow             string   `json:"flow,omitempty" yaml:"flow,omitempty"`                         // clientCredentials or password, password if username is given
+	TokenURL         string   `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`                 // override tokenUrl in document
+	ClientAuthInBody bool     `json:"clientAuthInBody,omitempty" yaml:"clientAuthInBody,omitempty"` // send client id and secret in form instead of basic auth
+}
+
+// requestToken tell whether token should be requested from token url
+func (c Credential) requestToken() bool {
+	return c.Token == "" && (c.ClientID != "" || c.Username != "")
+}
+
+// Lookup return credential of scheme, false if it is not configured
+type Lookup func(scheme openapi.SecurityScheme) (Credential, bool)
+
+// Status tell which schemes operation require and which of them are attached
+type Status struct {
+	Required  [][]string `json:"required"`            // alternatives of schemes, schemes in the same alternative are all required
+	Anonymous bool       `json:"anonymous,omitempty"` // operation can also be called without credential
+	Applied   []string   `json:"applied,omitempty"`   // schemes attached to request
+	Missing   []string   `json:"missing,omitempty"`   // schemes that need credential when nothing can be applied
+	Message   string     `json:"message,omitempty"`
+}
+
+// Apply attach credentials of the first requirement whose schemes are all configured,
+// credential already set by request itself will not be replaced
+func Apply(ctx context.Context, request *netclient.AIRequest, document *openapi.OpenAPI, requirements []openapi.SecurityRequirement, lookup Lookup) *Status {
+	status := &Status{
+		Required: make([][]string, 0, len(requirements)),
+	}
+
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement.Schemes))
+		for _, scheme := range requirement.Schemes {
+			names = append(names, scheme.Name)
+		}
+
+		if len(names) == 0 {
+			status.Anonymous = true
+		}
+
+		status.Required = append(status.Required, names)
+	}
+
+	if len(requirements) == 0 {
+		return status
+	}
+
+	var missing []string
+
+	for _, requirement := range requirements {
+		if len(requirement.Schemes) == 0 {
+			continue
+		}
+
+		schemes, credentials, notConfigured := resolve(document, requirement, lookup)
+		if len(notConfigured) > 0 {
+			missing = appendUnique(missing, notConfigured...)
+			continue
+		}
+
+		for i, scheme := range schemes {
+			if err := attach(ctx, request, scheme, credentials[i], requirement.Schemes[i].Scopes); err != nil {
+				status.Message = err.Error()
+				continue
+			}
+			status.Applied = append(status.Applied, scheme.Name)
+		}
+
+		return status
+	}
+
+	if !status.Anonymous {
+		status.Missing = missing
+		status.Message = fmt.Sprintf("No credential is configured for %s, request is sent without them", strings.Join(missing, ", "))
+	}
+
+	return status
+}
+
+// resolve find scheme and credential of every scheme in requirement
+func resolve(document *openapi.OpenAPI, requirement openapi.SecurityRequirement, lookup Lookup) ([]openapi.SecurityScheme, []Credential, []string) {
+	schemes := make([]openapi.SecurityScheme, 0, len(requirement.Schemes))
+	credentials := make([]Credential, 0, len(requirement.Schemes))
+	var notConfigured []string
+
+	for _, required := range requirement.Schemes {
+		scheme, ok := document.SecurityScheme(required.Name)
+		if !ok {
+			notConfigured = append(notConfigured, required.Name)
+			continue
+		}
+
+		credential, ok := lookup(scheme)
+		if !ok {
+			notConfigured = append(notConfigured, required.Name)
+			continue
+		}
+
+		schemes = append(schemes, scheme)
+		credentials = append(credentials, credential)
+	}
+
+	return schemes, credentials, notConfigured
+}
+
+// Attach put credential into request by type of scheme like Apply does for requirements of operation
+func Attach(ctx context.Context, request *netclient.AIRequest, scheme openapi.SecurityScheme, credential Credential) error {
+	return attach(ctx, request, scheme, credential, nil)
+}
+
+// attach put credential into header, query or cookie by type of scheme,
+// oauth2 token will be requested by Tokens if credential has client id or username instead of token
+func attach(ctx context.Context, request *netclient.AIRequest, scheme openapi.SecurityScheme, credential Credential, scopes []string) error {
+	if request.Headers == nil {
+		request.Headers = map[string]string{}
+	}
+	if request.QueryParams == nil {
+		request.QueryParams = map[string]string{}
+	}
+	if request.Cookies == nil {
+		request.Cookies = map[string]string{}
+	}
+
+	switch scheme.Type {
+	case "apiKey":
+		switch scheme.In {
+		case "query":
+			setIfAbsent(request.QueryParams, scheme.ParameterName, credential.Value)
+		case "cookie":
+			setIfAbsent(request.Cookies, scheme.ParameterName, credential.Value)
+		default:
+			SetHeaderIfAbsent(request.Headers, scheme.ParameterName, credential.Value)
+		}
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			SetHeaderIfAbsent(request.Headers, "Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credential.Username+":"+credential.Password)))
+		case "bearer":
+			SetHeaderIfAbsent(request.Headers, "Authorization", "Bearer "+credential.Token)
+		default:
+			// other schemes like digest need a full value like "Digest username=..."
+			SetHeaderIfAbsent(request.Headers, "Authorization", credential.Value)
+		}
+	case "oauth2":
+		if !credential.requestToken() {
+			SetHeaderIfAbsent(request.Headers, "Authorization", "Bearer "+credential.Token)
+			break
+		}
+
+		token, err := Tokens.Token(ctx, scheme, credential, scopes)
+		if err != nil {
+			return err
+		}
+		SetHeaderIfAbsent(request.Headers, "Authorization", token.TokenType+" "+token.AccessToken)
+	case "openIdConnect":
+		SetHeaderIfAbsent(request.Headers, "Authorization", "Bearer "+credential.Token)
+	default:
+		return fmt.Errorf("Security scheme %q with type %q can't be attached to request", scheme.Name, scheme.Type)
+	}
+
+	return nil
+}
+
+// Validate check credential has the fields that scheme need
+func Validate(scheme openapi.SecurityScheme, credential Credential) error {
+	switch scheme.Type {
+	case "apiKey":
+		if credential.Value == "" {
+			return fmt.Errorf("Value is required by apiKey scheme %q", scheme.Name)
+		}
+	case "http":
+		switch strings.ToLower(scheme.Scheme) {
+		case "basic":
+			if credential.Username == "" {
+				return fmt.Errorf("Username is required by basic scheme %q", scheme.Name)
+			}
+		case "bearer":
+			if credential.Token == "" {
+				return fmt.Errorf("Token is required by bearer scheme %q", scheme.Name)
+			}
+		default:
+			if credential.Value == "" {
+				return fmt.Errorf("Value of Authorization header is required by %s scheme %q", scheme.Scheme, scheme.Name)
+			}
+		}
+	case "oauth2":
+		if credential.Token == "" && credential.ClientID == "" && credential.Username == "" {
+			return fmt.Errorf("Token, client id or username is required by oauth2 scheme %q", scheme.Name)
+		}
+		if credential.requestToken() {
+			if _, err := flowOf(scheme, credential); err != nil {
+				return err
+			}
+		}
+	case "openIdConnect":
+		if credential.Token == "" {
+			return fmt.Errorf("Token is required by openIdConnect scheme %q", scheme.Name)
+		}
+	default:
+		return fmt.Errorf("Security scheme %q with type %q is not supported", scheme.Name, scheme.Type)
+	}
+
+	return nil
+}
+
+func setIfAbsent(values map[string]string, key string, value string) {
+	if _, ok := values[key]; !ok {
+		values[key] = value
+	}
+}
+
+// SetHeaderIfAbsent set header unless it exist, header name is checked case-insensitively
+func SetHeaderIfAbsent(headers map[string]string, key string, value string) {
+	for name := range headers {
+		if http.CanonicalHeaderKey(name) == http.CanonicalHeaderKey(key) {
+			return
+		}
+	}
+	headers[key] = value
+}
+
+func appendUnique(values []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, value := range values {
+			found = found || value == item
+		}
+		if !found {
+			values = append(values, item)
+		}
+	}
+	return values
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const securitySpec = `openapi: 3.0.3
+info:
+  title: Secure
+  version: 1.0.0
+security:
+  - bearerAuth: []
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: ok
+  /health:
+    get:
+      security: []
+      responses:
+        "200":
+          description: ok
+  /reports:
+    get:
+      security:
+        - apiKey: []
+          tenant: []
+        - basicAuth: []
+      responses:
+        "200":
+          description: ok
+  /public:
+    get:
+      security:
+        - {}
+        - bearerAuth: []
+      responses:
+        "200":
+          description: ok
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+    basicAuth:
+      type: http
+      scheme: basic
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    tenant:
+      type: apiKey
+      in: query
+      name: tenant
+`
+
+func readSecuritySpec(t *testing.T) *openapi.OpenAPI {
+	specPath := filepath.Join(t.TempDir(), "secure.yaml")
+	if err := os.WriteFile(specPath, []byte(securitySpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return document
+}
+
+func lookupOf(credentials map[string]Credential) Lookup {
+	return func(scheme openapi.SecurityScheme) (Credential, bool) {
+		credential, ok := credentials[scheme.Name]
+		return credential, ok
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	document := readSecuritySpec(t)
+
+	apply := func(path string, credentials map[string]Credential, request *netclient.AIRequest) *Status {
+		requirements, err := document.OperationSecurity(path, "get")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		return Apply(context.Background(), request, document, requirements, lookupOf(credentials))
+	}
+
+	request := &netclient.AIRequest{}
+	status := apply("/users", map[string]Credential{"bearerAuth": {Token: "abc"}}, request)
+	if request.Headers["Authorization"] != "Bearer abc" || !reflect.DeepEqual(status.Applied, []string{"bearerAuth"}) {
+		t.Errorf("Document security should be applied, got %v %+v", request.Headers, status)
+	}
+
+	request = &netclient.AIRequest{}
+	status = apply("/health", map[string]Credential{"bearerAuth": {Token: "abc"}}, request)
+	if len(status.Required) != 0 || request.Headers["Authorization"] != "" {
+		t.Errorf("Empty security of operation should remove document security, got %+v", status)
+	}
+
+	// the first alternative miss tenant so basic is used
+	request = &netclient.AIRequest{}
+	status = apply("/reports", map[string]Credential{"apiKey": {Value: "key"}, "basicAuth": {Username: "a", Password: "b"}}, request)
+	if request.Headers["Authorization"] != "Basic YTpi" || request.Headers["X-API-Key"] != "" {
+		t.Errorf("Alternative with every credential configured should be used, got %v", request.Headers)
+	}
+
+	request = &netclient.AIRequest{}
+	apply("/reports", map[string]Credential{"apiKey": {Value: "key"}, "tenant": {Value: "acme"}}, request)
+	if request.Headers["X-API-Key"] != "key" || request.QueryParams["tenant"] != "acme" {
+		t.Errorf("Every scheme of requirement should be applied, got %v %v", request.Headers, request.QueryParams)
+	}
+
+	request = &netclient.AIRequest{Headers: map[string]string{"authorization": "Bearer mine"}}
+	apply("/users", map[string]Credential{"bearerAuth": {Token: "abc"}}, request)
+	if request.Headers["authorization"] != "Bearer mine" || len(request.Headers) != 1 {
+		t.Errorf("Authorization set by request should be kept, got %v", request.Headers)
+	}
+
+	status = apply("/reports", nil, &netclient.AIRequest{})
+	if !reflect.DeepEqual(status.Missing, []string{"apiKey", "tenant", "basicAuth"}) {
+		t.Errorf("Missing schemes should be reported, got %v", status.Missing)
+	}
+
+	status = apply("/public", nil, &netclient.AIRequest{})
+	if !status.Anonymous || len(status.Missing) != 0 {
+		t.Errorf("Optional security should not report missing credential, got %+v", status)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	document := readSecuritySpec(t)
+
+	testCases := []struct {
+		scheme     string
+		credential Credential
+		valid      bool
+	}{
+		{"bearerAuth", Credential{Token: "abc"}, true},
+		{"bearerAuth", Credential{Value: "abc"}, false},
+		{"basicAuth", Credential{Username: "a"}, true},
+		{"apiKey", Credential{}, false},
+	}
+
+	for _, testCase := range testCases {
+		scheme, ok := document.SecurityScheme(testCase.scheme)
+		if !ok {
+			t.Fatalf("Scheme %q should be found", testCase.scheme)
+		}
+
+		if err := Validate(scheme, testCase.credential); (err == nil) != testCase.valid {
+			t.Errorf("Credential %+v of %q should be valid: %v, error: %v", testCase.credential, testCase.scheme, testCase.valid, err)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	openapi "mcp-api-tester/openAPI"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTokenLifetime is used when token response doesn't have expires_in
+	DefaultTokenLifetime = 5 * time.Minute
+
+	// expirySkew let token be refreshed a little before it really expire
+	expirySkew = 30 * time.Second
+
+	// tokenTimeout is the timeout of token request
+	tokenTimeout = 30 * time.Second
+
+	// maxTokenResponseSize limit how much of token response will be read
+	maxTokenResponseSize = 1 << 20
+)
+
+// Tokens cache oauth2 tokens of every scheme and client
+var Tokens = NewTokenManager()
+
+// Token is access token get from token url
+type Token struct {
+	AccessToken  string    `json:"accessToken"`
+	TokenType    string    `json:"tokenType"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Scope        string    `json:"scope,omitempty"`
+	refreshToken string
+}
+
+// TokenManager request oauth2 tokens by client credentials or password flow,
+// tokens are cached until they expire and refreshed by refresh token if possible
+type TokenManager struct {
+	mu      sync.Mutex
+	entries map[string]*tokenEntry
+	client  *http.Client
+	now     func() time.Time
+}
+
+// tokenEntry lock every token separately so slow token url will not block others
+type tokenEntry struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+// tokenResponse is the json of successful or failed token response in RFC 6749
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	Scope            string `json:"scope"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// NewTokenManager create TokenManager with empty cache
+func NewTokenManager() *TokenManager {
+	return &TokenManager{
+		entries: make(map[string]*tokenEntry),
+		client:  &http.Client{Timeout: tokenTimeout},
+		now:     time.Now,
+	}
+}
+
+// Token return cached token of scheme and credential or request a new one,
+// scopes of credential will be used if it has, otherwise scopes required by operation
+func (m *TokenManager) Token(ctx context.Context, scheme openapi.SecurityScheme, credential Credential, scopes []string) (*Token, error) {
+	flow, err := flowOf(scheme, credential)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(credential.Scopes) > 0 {
+		scopes = credential.Scopes
+	}
+
+	tokenURL := credential.TokenURL
+	if tokenURL == "" {
+		tokenURL = flow.TokenURL
+	}
+	if tokenURL == "" {
+		return nil, fmt.Errorf("Security scheme %q has no tokenUrl for %s flow", scheme.Name, flow.Type)
+	}
+
+	entry := m.entry(cacheKey(tokenURL, flow.Type, credential, scopes))
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.token != nil && m.now().Add(expirySkew).Before(entry.token.ExpiresAt) {
+		return entry.token, nil
+	}
+
+	// refresh token is tried first, new token will be requested if refresh failed
+	if entry.token != nil && entry.token.refreshToken != "" {
+		refreshURL := flow.RefreshURL
+		if refreshURL == "" {
+			refreshURL = tokenURL
+		}
+
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", entry.token.refreshToken)
+
+		if token, err := m.request(ctx, refreshURL, form, credential); err == nil {
+			if token.refreshToken == "" {
+				token.refreshToken = entry.token.refreshToken
+			}
+			entry.token = token
+			return token, nil
+		}
+	}
+
+	form := url.Values{}
+	if flow.Type == openapi.FlowPassword {
+		form.Set("grant_type", "password")
+		form.Set("username", credential.Username)
+		form.Set("password", credential.Password)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	token, err := m.request(ctx, tokenURL, form, credential)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when request token of %q from %q, error: %w", scheme.Name, tokenURL, err)
+	}
+
+	entry.token = token
+	return token, nil
+}
+
+// Clear forget every cached token
+func (m *TokenManager) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*tokenEntry)
+}
+
+func (m *TokenManager) entry(key string) *tokenEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		entry = &tokenEntry{}
+		m.entries[key] = entry
+	}
+
+	return entry
+}
+
+// request post form to token url, client is authenticated by basic auth unless ClientAuthInBody is set
+func (m *TokenManager) request(ctx context.Context, tokenURL string, form url.Values, credential Credential) (*Token, error) {
+	if credential.ClientAuthInBody {
+		form.Set("client_id", credential.ClientID)
+		if credential.ClientSecret != "" {
+			form.Set("client_secret", credential.ClientSecret)
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	if !credential.ClientAuthInBody && credential.ClientID != "" {
+		request.SetBasicAuth(url.QueryEscape(credential.ClientID), url.QueryEscape(credential.ClientSecret))
+	}
+
+	response, err := m.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxTokenResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded tokenResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("token response with status %d is not json", response.StatusCode)
+	}
+
+	if response.StatusCode >= 400 || decoded.Error != "" {
+		return nil, fmt.Errorf("token url response status %d %s %s", response.StatusCode, decoded.Error, decoded.ErrorDescription)
+	}
+
+	if decoded.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+
+	lifetime := DefaultTokenLifetime
+	if decoded.ExpiresIn > 0 {
+		lifetime = time.Duration(decoded.ExpiresIn) * time.Second
+	}
+
+	// some servers response lower case bearer which is not accepted by every resource server
+	tokenType := decoded.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	return &Token{
+		AccessToken:  decoded.AccessToken,
+		TokenType:    tokenType,
+		ExpiresAt:    m.now().Add(lifetime),
+		Scope:        decoded.Scope,
+		refreshToken: decoded.RefreshToken,
+	}, nil
+}
+
+// flowOf choose flow by credential, password flow is used when username is given
+func flowOf(scheme openapi.SecurityScheme, credential Credential) (openapi.OAuthFlow, error) {
+	flowType := credential.Flow
+	if flowType == "" {
+		flowType = openapi.FlowClientCredentials
+		if credential.Username != "" {
+			flowType = openapi.FlowPassword
+		}
+	}
+
+	if flowType != openapi.FlowClientCredentials && flowType != openapi.FlowPassword {
+		return openapi.OAuthFlow{}, fmt.Errorf("Only %s and %s flow can request token, not %q", openapi.FlowClientCredentials, openapi.FlowPassword, flowType)
+	}
+
+	flow, ok := scheme.Flow(flowType)
+	if !ok {
+		// token url given by credential is enough even if document doesn't declare the flow
+		if credential.TokenURL == "" {
+			return openapi.OAuthFlow{}, fmt.Errorf("Security scheme %q doesn't have %s flow", scheme.Name, flowType)
+		}
+		flow = openapi.OAuthFlow{Type: flowType}
+	}
+
+	return flow, nil
+}
+
+func cacheKey(tokenURL string, flowType string, credential Credential, scopes []string) string {
+	sorted := append([]string{}, scopes...)
+	sort.Strings(sorted)
+
+	return strings.Join([]string{tokenURL, flowType, credential.ClientID, credential.ClientSecret, credential.Username, credential.Password, strings.Join(sorted, " ")}, "\x00")
+}
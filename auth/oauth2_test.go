@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_TokenManager(t *testing.T) {
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("%v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Form.Get("grant_type") {
+		case "client_credentials":
+			clientID, clientSecret, ok := r.BasicAuth()
+			if !ok || clientID != "client" || clientSecret != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_client"})
+				return
+			}
+
+			if r.Form.Get("scope") != "read write" {
+				t.Errorf("Scopes should be joined by space, not %q", r.Form.Get("scope"))
+			}
+
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "first", "token_type": "bearer", "expires_in": 60, "refresh_token": "refresh"})
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != "refresh" {
+				t.Errorf("Refresh token should be sent, not %q", r.Form.Get("refresh_token"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "refreshed", "expires_in": 60})
+		case "password":
+			if r.Form.Get("username") != "alice" || r.Form.Get("client_id") != "client" {
+				t.Errorf("Username and client id in body should be sent, got %v", r.Form)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "password-token"})
+		}
+	}))
+	defer srv.Close()
+
+	spec := `openapi: 3.0.3
+info:
+  title: OAuth
+  version: 1.0.0
+security:
+  - oauth: [read, write]
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  securitySchemes:
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: ` + srv.URL + `/token
+          scopes:
+            read: read
+            write: write
+        password:
+          tokenUrl: ` + srv.URL + `/token
+          scopes: {}
+`
+	specPath := filepath.Join(t.TempDir(), "oauth.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	now := time.Now()
+	Tokens = NewTokenManager()
+	Tokens.now = func() time.Time { return now }
+	defer func() { Tokens = NewTokenManager() }()
+
+	requirements, err := document.OperationSecurity("/orders", "get")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	credential := Credential{ClientID: "client", ClientSecret: "secret"}
+	lookup := func(openapi.SecurityScheme) (Credential, bool) { return credential, true }
+
+	send := func() string {
+		request := &netclient.AIRequest{}
+		status := Apply(context.Background(), request, document, requirements, lookup)
+		if status.Message != "" {
+			t.Errorf("Token should be requested, got %q", status.Message)
+		}
+		return request.Headers["Authorization"]
+	}
+
+	if authorization := send(); authorization != "Bearer first" {
+		t.Errorf("Token from token url should be attached, not %q", authorization)
+	}
+
+	if authorization := send(); authorization != "Bearer first" || requests.Load() != 1 {
+		t.Errorf("Cached token should be used, got %q after %d requests", authorization, requests.Load())
+	}
+
+	// 30 seconds before expiry token should be refreshed
+	now = now.Add(45 * time.Second)
+	if authorization := send(); authorization != "Bearer refreshed" || requests.Load() != 2 {
+		t.Errorf("Token should be refreshed before expiry, got %q after %d requests", authorization, requests.Load())
+	}
+
+	credential = Credential{ClientID: "client", ClientSecret: "wrong"}
+	request := &netclient.AIRequest{}
+	status := Apply(context.Background(), request, document, requirements, lookup)
+	if status.Message == "" || request.Headers["Authorization"] != "" || len(status.Applied) != 0 {
+		t.Errorf("Rejected client should be reported, got %+v", status)
+	}
+
+	credential = Credential{ClientID: "client", Username: "alice", Password: "pw", ClientAuthInBody: true}
+	if authorization := send(); authorization != "Bearer password-token" {
+		t.Errorf("Password flow should be used when username is given, not %q", authorization)
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"sort"
+	"sync"
+)
+
+// Credentials keep credentials configured by each mcp session
+var Credentials = NewRegistry()
+
+// Registry store credentials of each mcp session by scheme name
+type Registry struct {
+	mu       sync.RWMutex
+	sessions map[string]map[string]Credential
+}
+
+// NewRegistry create an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[string]map[string]Credential),
+	}
+}
+
+// Set save credential of scheme for the session
+func (r *Registry) Set(sessionID string, scheme string, credential Credential) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	credentials, ok := r.sessions[sessionID]
+	if !ok {
+		credentials = make(map[string]Credential)
+		r.sessions[sessionID] = credentials
+	}
+
+	credentials[scheme] = credential
+}
+
+// Get return credential of scheme for the session
+func (r *Registry) Get(sessionID string, scheme string) (Credential, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credential, ok := r.sessions[sessionID][scheme]
+	return credential, ok
+}
+
+// Delete remove credential of scheme for the session
+func (r *Registry) Delete(sessionID string, scheme string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions[sessionID], scheme)
+}
+
+// Schemes return names of schemes that have credential for the session
+func (r *Registry) Schemes(sessionID string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemes := make([]string, 0, len(r.sessions[sessionID]))
+	for scheme := range r.sessions[sessionID] {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+
+	return schemes
+}
+
+// RemoveSession delete all credentials of the session, should be called when session closed
+func (r *Registry) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, sessionID)
+}
@@ -0,0 +1,118 @@
+package coverage
+
+import (
+	openapi "mcp-api-tester/openAPI"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const coverageSpec = `openapi: 3.0.3
+info:
+  title: Coverage
+  version: 1.0.0
+servers:
+  - url: https://example.com/v1
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Trace
+          in: header
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+        4XX:
+          description: client error
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: not found
+    delete:
+      responses:
+        "204":
+          description: deleted
+`
+
+func Test_Report(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "coverage.yaml")
+	if err := os.WriteFile(specPath, []byte(coverageSpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tracker := NewTracker()
+	tracker.Record(Request{Method: "get", URL: "https://example.com/v1/users?limit=10", StatusCode: 200})
+	tracker.Record(Request{Method: "GET", URL: "https://example.com/v1/users", Headers: map[string]string{"x-trace": "1"}, StatusCode: 400})
+	tracker.Record(Request{Method: "GET", URL: "https://example.com/v1/users/1", StatusCode: 500})
+	tracker.Record(Request{Method: "GET", URL: "https://example.com/v1/users/2", StatusCode: 200})
+	tracker.Record(Request{Method: "POST", URL: "https://example.com/v1/orders", StatusCode: 404})
+
+	report := tracker.Report(document)
+
+	if report.TotalOperations != 3 || report.CoveredOperations != 2 || report.Percent != 66.67 {
+		t.Errorf("Two of three operations should be covered, got %d/%d %v%%", report.CoveredOperations, report.TotalOperations, report.Percent)
+	}
+
+	operations := make(map[string]OperationCoverage)
+	for _, operation := range report.Operations {
+		operations[operation.Method+" "+operation.Path] = operation
+	}
+
+	listUsers := operations["GET /users"]
+	if listUsers.Hits != 2 || len(listUsers.UnexercisedParameters) != 0 || len(listUsers.UnobservedStatuses) != 0 {
+		t.Errorf("List users should be fully exercised, got %+v", listUsers)
+	}
+
+	getUser := operations["GET /users/{id}"]
+	if getUser.Hits != 2 || !reflect.DeepEqual(getUser.ObservedStatuses, []int{200, 500}) {
+		t.Errorf("Requests with different id should be merged into templated path, got %+v", getUser)
+	}
+
+	if !reflect.DeepEqual(getUser.UndocumentedStatuses, []int{500}) {
+		t.Errorf("500 should be undocumented, got %v", getUser.UndocumentedStatuses)
+	}
+
+	if !reflect.DeepEqual(getUser.UnobservedStatuses, []string{"404"}) {
+		t.Errorf("404 should be unobserved, got %v", getUser.UnobservedStatuses)
+	}
+
+	if deleteUser := operations["DELETE /users/{id}"]; deleteUser.Hits != 0 {
+		t.Errorf("Delete user should not be covered, got %+v", deleteUser)
+	}
+
+	if len(report.Unmatched) != 1 || report.Unmatched[0].Path != "/v1/orders" {
+		t.Errorf("Request to unknown path should be unmatched, got %v", report.Unmatched)
+	}
+
+	tracker.Record(Request{Method: "GET", URL: "https://example.com/v1/users"})
+	if report := tracker.Report(document); report.Operations[0].Hits != 3 {
+		t.Errorf("Request without response should still be counted as hit")
+	}
+
+	tracker.Reset()
+	if report := tracker.Report(document); report.CoveredOperations != 0 {
+		t.Errorf("Reset should forget every request")
+	}
+}
@@ -0,0 +1,172 @@
+package coverage
+
+import (
+	"fmt"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OperationCoverage is how much one operation has been exercised
+type OperationCoverage struct {
+	Method                string   `json:"method" yaml:"method"`
+	Path                  string   `json:"path" yaml:"path"`
+	OperationID           string   `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Hits                  int      `json:"hits" yaml:"hits"`
+	DocumentedStatuses    []string `json:"documentedStatuses" yaml:"documentedStatuses"`
+	ObservedStatuses      []int    `json:"observedStatuses" yaml:"observedStatuses"`
+	UndocumentedStatuses  []int    `json:"undocumentedStatuses,omitempty" yaml:"undocumentedStatuses,omitempty"`   // observed but not in document
+	UnobservedStatuses    []string `json:"unobservedStatuses,omitempty" yaml:"unobservedStatuses,omitempty"`       // documented but never observed
+	UnexercisedParameters []string `json:"unexercisedParameters,omitempty" yaml:"unexercisedParameters,omitempty"` // like query:limit
+}
+
+// UnmatchedRequest is request that doesn't match any operation in document
+type UnmatchedRequest struct {
+	Method string `json:"method" yaml:"method"`
+	Path   string `json:"path" yaml:"path"`
+	Hits   int    `json:"hits" yaml:"hits"`
+}
+
+// Report is coverage of a document
+type Report struct {
+	Spec              string              `json:"spec" yaml:"spec"`
+	TotalOperations   int                 `json:"totalOperations" yaml:"totalOperations"`
+	CoveredOperations int                 `json:"coveredOperations" yaml:"coveredOperations"`
+	Percent           float64             `json:"percent" yaml:"percent"`
+	Operations        []OperationCoverage `json:"operations" yaml:"operations"`
+	Unmatched         []UnmatchedRequest  `json:"unmatched" yaml:"unmatched"`
+}
+
+// operationKey is the key of operation like get /users/{id}
+func operationKey(method string, path string) string {
+	return strings.ToLower(method) + " " + path
+}
+
+// Report match recorded requests with operations of document
+func (t *Tracker) Report(document *openapi.OpenAPI) *Report {
+	hits := make(map[string]int)
+	statuses := make(map[string]map[int]bool)
+	parameters := make(map[string]map[string]bool)
+
+	report := &Report{
+		Spec:       document.Info().Source,
+		Operations: []OperationCoverage{},
+		Unmatched:  []UnmatchedRequest{},
+	}
+
+	for _, current := range t.snapshot() {
+		matched, err := document.FindOperation(current.method, current.path)
+		if err != nil {
+			report.Unmatched = append(report.Unmatched, UnmatchedRequest{
+				Method: current.method,
+				Path:   current.path,
+				Hits:   current.hits,
+			})
+			continue
+		}
+
+		key := operationKey(matched.Method, matched.Path)
+		hits[key] += current.hits
+
+		if statuses[key] == nil {
+			statuses[key] = map[int]bool{}
+			parameters[key] = map[string]bool{}
+		}
+		for status := range current.statuses {
+			statuses[key][status] = true
+		}
+		for name := range current.parameters {
+			parameters[key][name] = true
+		}
+	}
+
+	model := document.Model()
+
+	for pathPairs := model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		for operationPairs := pathPairs.Value().GetOperations().First(); operationPairs != nil; operationPairs = operationPairs.Next() {
+			operation := operationPairs.Value()
+			key := operationKey(operationPairs.Key(), pathPairs.Key())
+
+			operationCoverage := OperationCoverage{
+				Method:             strings.ToUpper(operationPairs.Key()),
+				Path:               pathPairs.Key(),
+				OperationID:        operation.OperationId,
+				Hits:               hits[key],
+				DocumentedStatuses: []string{},
+				ObservedStatuses:   []int{},
+			}
+
+			if operation.Responses != nil && operation.Responses.Codes != nil {
+				for codePairs := operation.Responses.Codes.First(); codePairs != nil; codePairs = codePairs.Next() {
+					operationCoverage.DocumentedStatuses = append(operationCoverage.DocumentedStatuses, codePairs.Key())
+				}
+			}
+			if operation.Responses != nil && operation.Responses.Default != nil {
+				operationCoverage.DocumentedStatuses = append(operationCoverage.DocumentedStatuses, "default")
+			}
+
+			for status := range statuses[key] {
+				operationCoverage.ObservedStatuses = append(operationCoverage.ObservedStatuses, status)
+				if _, response := validator.FindResponse(operation, status); response == nil {
+					operationCoverage.UndocumentedStatuses = append(operationCoverage.UndocumentedStatuses, status)
+				}
+			}
+			sort.Ints(operationCoverage.ObservedStatuses)
+			sort.Ints(operationCoverage.UndocumentedStatuses)
+
+			for _, documented := range operationCoverage.DocumentedStatuses {
+				if documented != "default" && !statusObserved(documented, statuses[key]) {
+					operationCoverage.UnobservedStatuses = append(operationCoverage.UnobservedStatuses, documented)
+				}
+			}
+
+			for _, parameter := range openapi.MergeParameters(pathPairs.Value().Parameters, operation.Parameters) {
+				// path params are always exercised once operation is hit
+				if parameter.In == "path" && hits[key] > 0 {
+					continue
+				}
+
+				if !parameters[key][parameterKey(parameter.In, parameter.Name)] {
+					operationCoverage.UnexercisedParameters = append(operationCoverage.UnexercisedParameters, parameter.In+":"+parameter.Name)
+				}
+			}
+
+			report.TotalOperations++
+			if operationCoverage.Hits > 0 {
+				report.CoveredOperations++
+			}
+
+			report.Operations = append(report.Operations, operationCoverage)
+		}
+	}
+
+	if report.TotalOperations > 0 {
+		percent, _ := strconv.ParseFloat(fmt.Sprintf("%.2f", float64(report.CoveredOperations)*100/float64(report.TotalOperations)), 64)
+		report.Percent = percent
+	}
+
+	sort.Slice(report.Unmatched, func(i, j int) bool {
+		if report.Unmatched[i].Path != report.Unmatched[j].Path {
+			return report.Unmatched[i].Path < report.Unmatched[j].Path
+		}
+		return report.Unmatched[i].Method < report.Unmatched[j].Method
+	})
+
+	return report
+}
+
+// statusObserved check documented code like 200 or 2XX against observed status codes
+func statusObserved(documented string, observed map[int]bool) bool {
+	for status := range observed {
+		code := strconv.Itoa(status)
+		if strings.EqualFold(documented, code) {
+			return true
+		}
+		if len(documented) == 3 && strings.EqualFold(documented[1:], "XX") && documented[0] == code[0] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+// Package coverage will track requests sent by tools and match them back to operations of OpenAPI document,
+// so we know which operations, status codes and parameters have been exercised
+package coverage
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// maxObserved limit how many distinct method and path a tracker keep,
+// request with new path will be ignored after that
+const maxObserved = 10000
+
+// Request is what tracker need to know about a sent request
+type Request struct {
+	Method      string
+	URL         string
+	QueryParams map[string]string
+	Headers     map[string]string
+	Cookies     map[string]string
+	StatusCode  int // 0 if no response received
+}
+
+// observed aggregate requests with the same method and real path
+type observed struct {
+	method     string
+	path       string
+	hits       int
+	statuses   map[int]int
+	parameters map[string]bool // like query:limit, header:X-Trace or cookie:session
+}
+
+// Tracker keep every request sent by one session
+type Tracker struct {
+	mu       sync.Mutex
+	observed map[string]*observed
+}
+
+// NewTracker create an empty tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		observed: make(map[string]*observed),
+	}
+}
+
+// Record save method, path, status code and names of params of request
+func (t *Tracker) Record(request Request) {
+	path := request.URL
+	queryNames := make([]string, 0, len(request.QueryParams))
+
+	if parsedURL, err := url.Parse(request.URL); err == nil {
+		path = parsedURL.Path
+		for name := range parsedURL.Query() {
+			queryNames = append(queryNames, name)
+		}
+	}
+
+	for name := range request.QueryParams {
+		queryNames = append(queryNames, name)
+	}
+
+	method := strings.ToUpper(request.Method)
+	key := method + " " + path
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current, ok := t.observed[key]
+	if !ok {
+		if len(t.observed) >= maxObserved {
+			return
+		}
+
+		current = &observed{
+			method:     method,
+			path:       path,
+			statuses:   map[int]int{},
+			parameters: map[string]bool{},
+		}
+		t.observed[key] = current
+	}
+
+	current.hits++
+
+	if request.StatusCode != 0 {
+		current.statuses[request.StatusCode]++
+	}
+
+	for _, name := range queryNames {
+		current.parameters[parameterKey("query", name)] = true
+	}
+	for name := range request.Headers {
+		current.parameters[parameterKey("header", name)] = true
+	}
+	for name := range request.Cookies {
+		current.parameters[parameterKey("cookie", name)] = true
+	}
+}
+
+// Reset forget every recorded request
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.observed = make(map[string]*observed)
+}
+
+// snapshot copy observed requests so report can be built without lock
+func (t *Tracker) snapshot() []observed {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := make([]observed, 0, len(t.observed))
+	for _, current := range t.observed {
+		copied := *current
+		copied.statuses = make(map[int]int, len(current.statuses))
+		for status, count := range current.statuses {
+			copied.statuses[status] = count
+		}
+		copied.parameters = make(map[string]bool, len(current.parameters))
+		for name := range current.parameters {
+			copied.parameters[name] = true
+		}
+		snapshot = append(snapshot, copied)
+	}
+
+	return snapshot
+}
+
+// parameterKey build key of param, header name is case-insensitive
+func parameterKey(in string, name string) string {
+	if in == "header" {
+		name = http.CanonicalHeaderKey(name)
+	}
+	return in + ":" + name
+}
+
+// Trackers keep tracker of each mcp session
+var Trackers = NewRegistry()
+
+// Registry store tracker of each mcp session
+type Registry struct {
+	mu       sync.Mutex
+	trackers map[string]*Tracker
+}
+
+// NewRegistry create an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		trackers: make(map[string]*Tracker),
+	}
+}
+
+// Get return tracker of session, it will be created if not exist
+func (r *Registry) Get(sessionID string) *Tracker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tracker, ok := r.trackers[sessionID]
+	if !ok {
+		tracker = NewTracker()
+		r.trackers[sessionID] = tracker
+	}
+
+	return tracker
+}
+
+// RemoveSession delete tracker of the session, should be called when session closed
+func (r *Registry) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.trackers, sessionID)
+}
@@ -0,0 +1,268 @@
+// Package environment define named profiles like dev, staging or local in a YAML or JSON file,
+// every profile provide base url, default headers, variables and credentials of requests
+// so relative path like /users/{id} can be resolved against the active profile
+package environment
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/auth"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Auth types that profile support
+const (
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+	AuthAPIKey = "apiKey"
+)
+
+// Auth is the credential that will be attached to every request,
+// it is attached like a security scheme of OpenAPI file so both share the same rules
+type Auth struct {
+	Type string `json:"type" yaml:"type"`                     // bearer, basic or apiKey
+	Name string `json:"name,omitempty" yaml:"name,omitempty"` // header, query or cookie name used by apiKey
+	In   string `json:"in,omitempty" yaml:"in,omitempty"`     // header, query or cookie, default is header
+
+	// token for bearer, username and password for basic, value for apiKey
+	auth.Credential `yaml:",inline"`
+}
+
+// Profile is one environment like dev or staging
+type Profile struct {
+	Name      string            `json:"name" yaml:"name"`
+	BaseURL   string            `json:"baseURL,omitempty" yaml:"baseURL,omitempty"` // the first server of document will be used if empty
+	Headers   map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"` // used by {{name}} and path param like {id}
+	Auth      *Auth             `json:"auth,omitempty" yaml:"auth,omitempty"`
+
+	// Credentials of security schemes in OpenAPI file by scheme name, attached when operation require them
+	Credentials map[string]auth.Credential `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+}
+
+// Config is the content of environment file
+type Config struct {
+	Default      string              `json:"default,omitempty" yaml:"default,omitempty"` // profile that is active at start
+	Environments map[string]*Profile `json:"environments" yaml:"environments"`
+}
+
+// envPattern match ${NAME} so secrets can be kept in environment variables instead of file
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// templatePattern match {{name}}
+var templatePattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// pathParamPattern match {name} in path
+var pathParamPattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// Load read config from YAML or JSON file
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when read environment file %q, error: %w", path, err)
+	}
+
+	config, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when parse environment file %q, error: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// Parse decode config from YAML or JSON content, ${NAME} in values will be replaced by environment variable
+func Parse(content []byte) (*Config, error) {
+	var config Config
+
+	// JSON is valid YAML so one decoder is enough
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, err
+	}
+
+	if len(config.Environments) == 0 {
+		return nil, fmt.Errorf("Environment file has no environment")
+	}
+
+	for name, profile := range config.Environments {
+		if profile == nil {
+			profile = &Profile{}
+			config.Environments[name] = profile
+		}
+
+		profile.Name = name
+		profile.expandEnv()
+
+		if err := profile.Auth.validate(); err != nil {
+			return nil, fmt.Errorf("Environment %q has invalid auth, error: %w", name, err)
+		}
+	}
+
+	if config.Default != "" && config.Environments[config.Default] == nil {
+		return nil, fmt.Errorf("Default environment %q is not defined", config.Default)
+	}
+
+	return &config, nil
+}
+
+func (p *Profile) expandEnv() {
+	p.BaseURL = expandEnv(p.BaseURL)
+
+	for key, value := range p.Headers {
+		p.Headers[key] = expandEnv(value)
+	}
+
+	for key, value := range p.Variables {
+		p.Variables[key] = expandEnv(value)
+	}
+
+	for name, credential := range p.Credentials {
+		p.Credentials[name] = expandCredential(credential)
+	}
+
+	if p.Auth != nil {
+		p.Auth.Credential = expandCredential(p.Auth.Credential)
+	}
+}
+
+func expandCredential(credential auth.Credential) auth.Credential {
+	credential.Token = expandEnv(credential.Token)
+	credential.Username = expandEnv(credential.Username)
+	credential.Password = expandEnv(credential.Password)
+	credential.Value = expandEnv(credential.Value)
+	credential.ClientID = expandEnv(credential.ClientID)
+	credential.ClientSecret = expandEnv(credential.ClientSecret)
+	return credential
+}
+
+func expandEnv(value string) string {
+	return envPattern.ReplaceAllStringFunc(value, func(match string) string {
+		return os.Getenv(envPattern.FindStringSubmatch(match)[1])
+	})
+}
+
+func (a *Auth) validate() error {
+	if a == nil {
+		return nil
+	}
+
+	switch a.Type {
+	case AuthBearer, AuthBasic:
+		return nil
+	case AuthAPIKey:
+		if a.Name == "" {
+			return fmt.Errorf("name of apiKey is required")
+		}
+		switch a.In {
+		case "", "header", "query", "cookie":
+			return nil
+		}
+		return fmt.Errorf("apiKey can only be in header, query or cookie, not %q", a.In)
+	}
+
+	return fmt.Errorf("auth type should be %s, %s or %s, not %q", AuthBearer, AuthBasic, AuthAPIKey, a.Type)
+}
+
+// Render replace {{name}} in value by variables of profile, unknown template will be kept
+func (p *Profile) Render(value string) string {
+	if p == nil || len(p.Variables) == 0 {
+		return value
+	}
+
+	return templatePattern.ReplaceAllStringFunc(value, func(match string) string {
+		if variable, ok := p.Variables[templatePattern.FindStringSubmatch(match)[1]]; ok {
+			return variable
+		}
+		return match
+	})
+}
+
+// ResolveURL render rawURL, fill path params like {id} by pathParams then variables,
+// and prepend base url of profile or fallbackBaseURL if rawURL is a path start with /
+func (p *Profile) ResolveURL(rawURL string, pathParams map[string]string, fallbackBaseURL string) (string, error) {
+	resolved := p.Render(rawURL)
+
+	if !strings.HasPrefix(resolved, "/") {
+		return resolved, nil
+	}
+
+	path, query, _ := strings.Cut(resolved, "?")
+
+	var missing []string
+	path = pathParamPattern.ReplaceAllStringFunc(path, func(match string) string {
+		name := pathParamPattern.FindStringSubmatch(match)[1]
+		if value, ok := pathParams[name]; ok {
+			return value
+		}
+		if p != nil {
+			if value, ok := p.Variables[name]; ok {
+				return value
+			}
+		}
+		missing = append(missing, name)
+		return match
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("Path params %s of url %q have no value, provide them by pathParams or variables of environment", strings.Join(missing, ", "), rawURL)
+	}
+
+	baseURL := fallbackBaseURL
+	if p != nil && p.BaseURL != "" {
+		baseURL = p.BaseURL
+	}
+
+	if baseURL == "" {
+		return "", fmt.Errorf("Url %q is relative but no environment or server of OpenAPI file provide base url", rawURL)
+	}
+
+	resolved = strings.TrimRight(baseURL, "/") + path
+	if query != "" {
+		resolved += "?" + query
+	}
+
+	return resolved, nil
+}
+
+// Apply render templates of request and add default headers and credentials that request hasn't set
+func (p *Profile) Apply(request *netclient.AIRequest) {
+	if p == nil {
+		return
+	}
+
+	request.Headers = p.renderMap(request.Headers)
+	request.QueryParams = p.renderMap(request.QueryParams)
+	request.Cookies = p.renderMap(request.Cookies)
+	request.Body = p.Render(request.Body)
+
+	for key, value := range p.Headers {
+		auth.SetHeaderIfAbsent(request.Headers, key, p.Render(value))
+	}
+
+	if p.Auth != nil {
+		// bearer, basic and apiKey never request token so attach can't fail
+		_ = auth.Attach(context.Background(), request, p.Auth.scheme(), p.Auth.Credential)
+	}
+}
+
+func (p *Profile) renderMap(values map[string]string) map[string]string {
+	rendered := make(map[string]string, len(values))
+	for key, value := range values {
+		rendered[key] = p.Render(value)
+	}
+	return rendered
+}
+
+// scheme describe auth as security scheme, credential given by request itself will not be replaced
+func (a *Auth) scheme() openapi.SecurityScheme {
+	switch a.Type {
+	case AuthBearer, AuthBasic:
+		return openapi.SecurityScheme{Name: a.Type, Type: "http", Scheme: a.Type}
+	}
+	return openapi.SecurityScheme{Name: a.Type, Type: "apiKey", In: a.In, ParameterName: a.Name}
+}
@@ -0,0 +1,188 @@
+package environment
+
+import (
+	netclient "mcp-api-tester/net/client"
+	"testing"
+)
+
+const environmentFile = `default: dev
+environments:
+  dev:
+    baseURL: http://localhost:8080/v1
+    headers:
+      X-Tenant: "{{tenant}}"
+    variables:
+      tenant: acme
+      id: "42"
+    auth:
+      type: bearer
+      token: ${ENVIRONMENT_TEST_TOKEN}
+  staging:
+    auth:
+      type: apiKey
+      name: api_key
+      in: query
+      value: secret
+`
+
+func Test_Parse(t *testing.T) {
+	t.Setenv("ENVIRONMENT_TEST_TOKEN", "token-from-env")
+
+	config, err := Parse([]byte(environmentFile))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	dev := config.Environments["dev"]
+	if dev.Name != "dev" || dev.Auth.Token != "token-from-env" {
+		t.Errorf("Name should be filled and ${NAME} should be expanded, got %+v %+v", dev, dev.Auth)
+	}
+
+	t.Setenv("ENVIRONMENT_TEST_USER", "alice")
+	t.Setenv("ENVIRONMENT_TEST_PASSWORD", "p@ss")
+	t.Setenv("ENVIRONMENT_TEST_KEY", "key-from-env")
+
+	config, err = Parse([]byte(`environments:
+  basic:
+    auth:
+      type: basic
+      username: ${ENVIRONMENT_TEST_USER}
+      password: ${ENVIRONMENT_TEST_PASSWORD}
+  key:
+    auth:
+      type: apiKey
+      name: X-API-Key
+      value: ${ENVIRONMENT_TEST_KEY}
+`))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if basic := config.Environments["basic"].Auth; basic.Username != "alice" || basic.Password != "p@ss" {
+		t.Errorf("Username and password of basic auth should be expanded, got %+v", basic)
+	}
+
+	if key := config.Environments["key"].Auth; key.Value != "key-from-env" {
+		t.Errorf("Value of apiKey auth should be expanded, got %+v", key)
+	}
+
+	if _, err := Parse([]byte("default: prod\nenvironments:\n  dev: {}\n")); err == nil {
+		t.Errorf("Undefined default environment should be rejected")
+	}
+
+	if _, err := Parse([]byte("environments:\n  dev:\n    auth:\n      type: digest\n")); err == nil {
+		t.Errorf("Unknown auth type should be rejected")
+	}
+}
+
+func Test_ResolveURL(t *testing.T) {
+	config, err := Parse([]byte(environmentFile))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	dev := config.Environments["dev"]
+	staging := config.Environments["staging"]
+
+	cases := []struct {
+		profile    *Profile
+		rawURL     string
+		pathParams map[string]string
+		fallback   string
+		expected   string
+	}{
+		{dev, "/users/{id}?tenant={{tenant}}", nil, "https://fallback.example.com", "http://localhost:8080/v1/users/42?tenant=acme"},
+		{dev, "/users/{id}", map[string]string{"id": "7"}, "", "http://localhost:8080/v1/users/7"},
+		{staging, "/users", nil, "https://fallback.example.com/", "https://fallback.example.com/users"},
+		{nil, "https://example.com/users/{id}", nil, "", "https://example.com/users/{id}"},
+	}
+
+	for _, c := range cases {
+		resolved, err := c.profile.ResolveURL(c.rawURL, c.pathParams, c.fallback)
+		if err != nil {
+			t.Errorf("Url %q should be resolved, error: %v", c.rawURL, err)
+			continue
+		}
+		if resolved != c.expected {
+			t.Errorf("Url %q should be resolved to %q, not %q", c.rawURL, c.expected, resolved)
+		}
+	}
+
+	if _, err := staging.ResolveURL("/users/{id}", nil, "https://example.com"); err == nil {
+		t.Errorf("Path param without value should be an error")
+	}
+
+	if _, err := staging.ResolveURL("/users", nil, ""); err == nil {
+		t.Errorf("Relative url without any base url should be an error")
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	t.Setenv("ENVIRONMENT_TEST_TOKEN", "token")
+
+	config, err := Parse([]byte(environmentFile))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	request := &netclient.AIRequest{Body: `{"tenant":"{{tenant}}"}`}
+	config.Environments["dev"].Apply(request)
+
+	if request.Headers["X-Tenant"] != "acme" || request.Headers["Authorization"] != "Bearer token" {
+		t.Errorf("Default headers and bearer token should be added, got %v", request.Headers)
+	}
+
+	if request.Body != `{"tenant":"acme"}` {
+		t.Errorf("Variables in body should be rendered, got %s", request.Body)
+	}
+
+	request = &netclient.AIRequest{Headers: map[string]string{"authorization": "Bearer mine"}}
+	config.Environments["dev"].Apply(request)
+	if request.Headers["authorization"] != "Bearer mine" || request.Headers["Authorization"] != "" {
+		t.Errorf("Authorization given by request should be kept, got %v", request.Headers)
+	}
+
+	request = &netclient.AIRequest{}
+	config.Environments["staging"].Apply(request)
+	if request.QueryParams["api_key"] != "secret" {
+		t.Errorf("Api key should be added to query, got %v", request.QueryParams)
+	}
+}
+
+func Test_Registry(t *testing.T) {
+	config, err := Parse([]byte(environmentFile))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	registry := NewRegistry()
+	if registry.Active("a") != nil {
+		t.Errorf("No environment should be active before config is loaded")
+	}
+
+	registry.SetDefault(config)
+	if profile := registry.Active("a"); profile == nil || profile.Name != "dev" {
+		t.Errorf("Default environment should be active, got %v", profile)
+	}
+
+	if _, err := registry.Use("a", "staging"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if registry.Active("a").Name != "staging" || registry.Active("b").Name != "dev" {
+		t.Errorf("Switching environment should only affect one session")
+	}
+
+	if _, err := registry.Use("a", "prod"); err == nil {
+		t.Errorf("Undefined environment should not be used")
+	}
+
+	summaries := registry.List("a")
+	if len(summaries) != 2 || summaries[0].Name != "dev" || !summaries[1].Active || summaries[1].AuthType != AuthAPIKey {
+		t.Errorf("List should be sorted and mark active environment, got %+v", summaries)
+	}
+
+	registry.RemoveSession("a")
+	if registry.Active("a").Name != "dev" {
+		t.Errorf("Removed session should fall back to default environment")
+	}
+}
@@ -0,0 +1,160 @@
+package environment
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Environments keep environment config and active profile of each mcp session
+var Environments = NewRegistry()
+
+// Registry store environment config of each mcp session,
+// session that hasn't loaded its own config will use the default config
+type Registry struct {
+	mu       sync.RWMutex
+	config   *Config // loaded by -env-file flag
+	sessions map[string]*sessionEnvironment
+}
+
+type sessionEnvironment struct {
+	config *Config
+	active string
+}
+
+// Summary describe a profile without its credentials
+type Summary struct {
+	Name      string   `json:"name" yaml:"name"`
+	Active    bool     `json:"active" yaml:"active"`
+	BaseURL   string   `json:"baseURL,omitempty" yaml:"baseURL,omitempty"`
+	Headers   []string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Variables []string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	AuthType  string   `json:"authType,omitempty" yaml:"authType,omitempty"`
+	Schemes   []string `json:"schemes,omitempty" yaml:"schemes,omitempty"` // security schemes that have credential
+}
+
+// NewRegistry create an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[string]*sessionEnvironment),
+	}
+}
+
+// SetDefault set config that every session start with
+func (r *Registry) SetDefault(config *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.config = config
+}
+
+// Load replace config of the session, default profile of config become active
+func (r *Registry) Load(sessionID string, config *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[sessionID] = &sessionEnvironment{
+		config: config,
+		active: config.Default,
+	}
+}
+
+// Use switch active profile of the session
+func (r *Registry) Use(sessionID string, name string) (*Profile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session := r.session(sessionID)
+	if session.config == nil {
+		return nil, fmt.Errorf("No environment file has been loaded")
+	}
+
+	profile, ok := session.config.Environments[name]
+	if !ok {
+		return nil, fmt.Errorf("Environment %q is not defined", name)
+	}
+
+	session.active = name
+	r.sessions[sessionID] = session
+
+	return profile, nil
+}
+
+// Active return active profile of the session, nil will be return if no profile is active
+func (r *Registry) Active(sessionID string) *Profile {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session := r.session(sessionID)
+	if session.config == nil {
+		return nil
+	}
+
+	return session.config.Environments[session.active]
+}
+
+// List return every profile of the session sorted by name
+func (r *Registry) List(sessionID string) []Summary {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session := r.session(sessionID)
+	if session.config == nil {
+		return []Summary{}
+	}
+
+	summaries := make([]Summary, 0, len(session.config.Environments))
+	for name, profile := range session.config.Environments {
+		summary := Summary{
+			Name:      name,
+			Active:    name == session.active,
+			BaseURL:   profile.BaseURL,
+			Headers:   sortedKeys(profile.Headers),
+			Variables: sortedKeys(profile.Variables),
+		}
+		for scheme := range profile.Credentials {
+			summary.Schemes = append(summary.Schemes, scheme)
+		}
+		sort.Strings(summary.Schemes)
+		if profile.Auth != nil {
+			summary.AuthType = profile.Auth.Type
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries
+}
+
+// RemoveSession delete environment of the session, should be called when session closed
+func (r *Registry) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, sessionID)
+}
+
+// session return environment of session or a copy of default one, lock should be held by caller
+func (r *Registry) session(sessionID string) *sessionEnvironment {
+	if session, ok := r.sessions[sessionID]; ok {
+		return session
+	}
+
+	session := &sessionEnvironment{config: r.config}
+	if r.config != nil {
+		session.active = r.config.Default
+	}
+	return session
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,315 @@
+// Package fuzzer will walk parameters and request body schema of an operation
+// and generate mutated requests like boundary numbers, overlong strings, wrong types,
+// missing required fields, injection strings, invalid enums and unicode edge cases
+package fuzzer
+
+import (
+	"encoding/json"
+	"mcp-api-tester/generator"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+	"net/url"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+const (
+	// DefaultMaxCases will be used when Options.MaxCases is not provided
+	DefaultMaxCases = 50
+
+	// MaxCasesLimit is the hard limit of cases can be generated at once
+	MaxCasesLimit = 500
+
+	// maxBodyDepth limit how deep the body will be walked
+	maxBodyDepth = 3
+)
+
+// ExpectedClass is the class of status code that server should response
+type ExpectedClass string
+
+const (
+	// Expect4XX means server should reject the request
+	Expect4XX ExpectedClass = "4xx"
+
+	// Expect2XX means request is still valid and server should accept it
+	Expect2XX ExpectedClass = "2xx"
+)
+
+// Options control which mutations will be generated
+type Options struct {
+	Categories []Category // all categories will be used if empty
+	MaxCases   int
+}
+
+// Case is one mutated request
+type Case struct {
+	Request     generator.ExampleRequest `json:"request" yaml:"request"`
+	Category    Category                 `json:"category" yaml:"category"`
+	Target      string                   `json:"target" yaml:"target"` // json pointer like /query/limit or /body/name
+	Description string                   `json:"description" yaml:"description"`
+	Expected    ExpectedClass            `json:"expected" yaml:"expected"`
+}
+
+// Generate return mutated requests of operation, every case only mutate one field of a valid request
+func Generate(matched *openapi.MatchedOperation, baseURL string, options Options) []Case {
+	maxCases := options.MaxCases
+	if maxCases <= 0 {
+		maxCases = DefaultMaxCases
+	}
+	maxCases = min(maxCases, MaxCasesLimit)
+
+	categories := make(map[Category]bool)
+	for _, category := range options.Categories {
+		categories[category] = true
+	}
+	if len(categories) == 0 {
+		for _, category := range AllCategories {
+			categories[category] = true
+		}
+	}
+
+	valid := generator.NewExampleRequest(matched, "", generator.Options{IncludeOptional: true})
+	pathValues := pathValuesOf(matched)
+
+	f := &fuzz{
+		matched:    matched,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		base:       valid,
+		pathValues: pathValues,
+		categories: categories,
+		maxCases:   maxCases,
+	}
+
+	f.fuzzParameters()
+	f.fuzzBody()
+
+	return f.cases
+}
+
+// fuzz keep state while generating cases
+type fuzz struct {
+	matched    *openapi.MatchedOperation
+	baseURL    string
+	base       generator.ExampleRequest
+	pathValues map[string]string
+	categories map[Category]bool
+	maxCases   int
+	cases      []Case
+}
+
+func (f *fuzz) full() bool {
+	return len(f.cases) >= f.maxCases
+}
+
+func (f *fuzz) fuzzParameters() {
+	for _, parameter := range f.matched.Parameters {
+		var schema *base.Schema
+		if parameter.Schema != nil {
+			schema = parameter.Schema.Schema()
+		}
+
+		required := parameter.In == "path" || (parameter.Required != nil && *parameter.Required)
+		target := "/" + parameter.In + "/" + parameter.Name
+
+		for _, mutation := range mutationsOf(schema, required, f.categories) {
+			if f.full() {
+				return
+			}
+
+			// every value of parameter is string, so number is not wrong type of string
+			if mutation.category == WrongType && schema != nil && firstType(schema) == "string" {
+				continue
+			}
+
+			// path param can't be removed from url
+			if mutation.remove && parameter.In == "path" {
+				continue
+			}
+
+			value := generator.ParameterString(mutation.value)
+			request := f.copyBase()
+
+			switch parameter.In {
+			case "path":
+				pathValues := cloneMap(f.pathValues)
+				pathValues[parameter.Name] = value
+				request.URL = f.buildURL(pathValues)
+			case "query":
+				setOrDelete(request.QueryParams, parameter.Name, value, mutation.remove)
+			case "header":
+				setOrDelete(request.Headers, parameter.Name, value, mutation.remove)
+			case "cookie":
+				setOrDelete(request.Cookies, parameter.Name, value, mutation.remove)
+			}
+
+			expected := Expect4XX
+			if !mutation.remove && len(validator.Validate(schema, validator.CoerceParameter(schema, value), "")) == 0 {
+				expected = Expect2XX
+			}
+
+			f.cases = append(f.cases, Case{
+				Request:     request,
+				Category:    mutation.category,
+				Target:      target,
+				Description: mutation.description,
+				Expected:    expected,
+			})
+		}
+	}
+}
+
+func (f *fuzz) fuzzBody() {
+	requestBody := f.matched.Operation.RequestBody
+	if requestBody == nil || !validator.IsJSONMediaType(f.base.ContentType) {
+		return
+	}
+
+	mediaType := requestBody.Content.GetOrZero(f.base.ContentType)
+	if mediaType == nil || mediaType.Schema == nil {
+		return
+	}
+
+	f.fuzzObject(mediaType.Schema.Schema(), nil, 0)
+}
+
+// fuzzObject mutate every property of object schema, path is where the object located in body
+func (f *fuzz) fuzzObject(schema *base.Schema, path []string, depth int) {
+	if schema == nil || schema.Properties == nil || depth >= maxBodyDepth {
+		return
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+		propertySchema := pair.Value().Schema()
+		if propertySchema == nil || (propertySchema.ReadOnly != nil && *propertySchema.ReadOnly) {
+			continue
+		}
+
+		propertyPath := append(append([]string{}, path...), pair.Key())
+
+		for _, mutation := range mutationsOf(propertySchema, required[pair.Key()], f.categories) {
+			if f.full() {
+				return
+			}
+
+			request := f.copyBase()
+			request.Body = setInBody(request.Body, propertyPath, mutation)
+
+			expected := Expect4XX
+			if !mutation.remove && len(validator.Validate(propertySchema, mutation.value, "")) == 0 {
+				expected = Expect2XX
+			}
+
+			f.cases = append(f.cases, Case{
+				Request:     request,
+				Category:    mutation.category,
+				Target:      "/body/" + strings.Join(propertyPath, "/"),
+				Description: mutation.description,
+				Expected:    expected,
+			})
+		}
+
+		if firstType(propertySchema) == "object" {
+			f.fuzzObject(propertySchema, propertyPath, depth+1)
+		}
+	}
+}
+
+// copyBase deep copy the valid request so mutation will not affect others
+func (f *fuzz) copyBase() generator.ExampleRequest {
+	request := f.base
+	request.URL = f.buildURL(f.pathValues)
+	request.Headers = cloneMap(f.base.Headers)
+	request.Cookies = cloneMap(f.base.Cookies)
+	request.QueryParams = cloneMap(f.base.QueryParams)
+	request.Body = deepCopy(f.base.Body)
+	return request
+}
+
+func (f *fuzz) buildURL(pathValues map[string]string) string {
+	path := f.matched.Path
+	for name, value := range pathValues {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+	return f.baseURL + path
+}
+
+// pathValuesOf generate valid value of every path param
+func pathValuesOf(matched *openapi.MatchedOperation) map[string]string {
+	pathValues := make(map[string]string)
+
+	for _, parameter := range matched.Parameters {
+		if parameter.In == "path" {
+			pathValues[parameter.Name] = generator.ParameterString(generator.ExampleFromParameter(parameter, generator.Options{}))
+		}
+	}
+
+	return pathValues
+}
+
+// setInBody replace or remove value located by path in json body
+func setInBody(body any, path []string, mutation mutation) any {
+	object, ok := body.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+
+	current := object
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+
+	last := path[len(path)-1]
+	if mutation.remove {
+		delete(current, last)
+	} else {
+		current[last] = mutation.value
+	}
+
+	return object
+}
+
+func setOrDelete(values map[string]string, key string, value string, remove bool) {
+	if remove {
+		delete(values, key)
+		return
+	}
+	values[key] = value
+}
+
+func cloneMap(values map[string]string) map[string]string {
+	cloned := make(map[string]string, len(values))
+	for key, value := range values {
+		cloned[key] = value
+	}
+	return cloned
+}
+
+// deepCopy copy json value by marshal and unmarshal
+func deepCopy(value any) any {
+	if value == nil {
+		return nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var copied any
+	if err := json.Unmarshal(valueBytes, &copied); err != nil {
+		return value
+	}
+
+	return copied
+}
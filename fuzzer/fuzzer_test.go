@@ -0,0 +1,301 @@
+package fuzzer
+
+import (
+	"encoding/json"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const fuzzerSpec = `openapi: 3.0.3
+info:
+  title: Fuzzer
+  version: 1.0.0
+paths:
+  /users/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+            minimum: 1
+            maximum: 100
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, role]
+              properties:
+                id:
+                  type: integer
+                  readOnly: true
+                name:
+                  type: string
+                  minLength: 2
+                  maxLength: 8
+                role:
+                  type: string
+                  enum: [admin, user]
+                address:
+                  type: object
+                  required: [city]
+                  properties:
+                    city:
+                      type: string
+      responses:
+        "200":
+          description: ok
+`
+
+func readMatched(t *testing.T) *openapi.MatchedOperation {
+	t.Helper()
+
+	specPath := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(specPath, []byte(fuzzerSpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	matched, err := document.GetMatchedOperation("/users/{id}", "put")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return matched
+}
+
+func Test_Generate(t *testing.T) {
+	matched := readMatched(t)
+
+	cases := Generate(matched, "http://localhost/", Options{MaxCases: MaxCasesLimit})
+	if len(cases) == 0 {
+		t.Fatalf("Cases should be generated")
+	}
+
+	found := make(map[string]Case)
+	for _, c := range cases {
+		found[c.Target+" "+c.Description] = c
+
+		if c.Target == "/body/id" {
+			t.Errorf("ReadOnly property should not be fuzzed")
+		}
+
+		if c.Target != "/path/id" && c.Request.URL != "http://localhost/users/1" {
+			t.Errorf("Only path mutation should change url, got %q", c.Request.URL)
+		}
+	}
+
+	expectations := map[string]ExpectedClass{
+		"/query/limit use maximum":                            Expect2XX,
+		"/query/limit use value above maximum":                Expect4XX,
+		"/query/limit remove required field":                  Expect4XX,
+		"/path/id use value below minimum":                    Expect4XX,
+		"/body/name use string with maxLength":                Expect2XX,
+		"/body/name use string longer than maxLength":         Expect4XX,
+		"/body/name use number instead of string":             Expect4XX,
+		"/body/role use value that is not in enum":            Expect4XX,
+		"/body/address/city remove required field":            Expect4XX,
+		"/body/name use string with 10000 characters":         Expect4XX,
+		"/body/address/city use injection string ' OR '1'='1": Expect2XX,
+	}
+
+	for key, expected := range expectations {
+		c, ok := found[key]
+		if !ok {
+			t.Errorf("Case %q should be generated", key)
+			continue
+		}
+
+		if c.Expected != expected {
+			t.Errorf("Case %q should expect %s, not %s", key, expected, c.Expected)
+		}
+	}
+
+	if c := found["/path/id use value below minimum"]; c.Request.URL != "http://localhost/users/0" {
+		t.Errorf("Path mutation should be put in url, got %q", c.Request.URL)
+	}
+
+	if c := found["/query/limit remove required field"]; c.Request.QueryParams["limit"] != "" {
+		t.Errorf("Required query param should be removed, got %v", c.Request.QueryParams)
+	}
+
+	// Mutation of nested property should not leak into other cases
+	c := found["/body/address/city remove required field"]
+	body, _ := json.Marshal(c.Request.Body)
+	if strings.Contains(string(body), "city") {
+		t.Errorf("City should be removed from body, got %s", body)
+	}
+
+	result := validator.ValidateRequest(matched.Operation, matched.Parameters, validator.Request{
+		PathParams:  map[string]string{"id": "1"},
+		QueryParams: cases[0].Request.QueryParams,
+		ContentType: "application/json",
+		Body:        mustJSON(t, found["/body/name use string with maxLength"].Request.Body),
+	})
+	if !result.Valid {
+		t.Errorf("Case expecting 2xx should pass validation, got %v", result.Violations)
+	}
+}
+
+func Test_GenerateOptions(t *testing.T) {
+	matched := readMatched(t)
+
+	cases := Generate(matched, "", Options{Categories: []Category{InvalidEnum}})
+	if len(cases) != 1 || cases[0].Target != "/body/role" {
+		t.Errorf("Only invalid enum of role should be generated, got %v", cases)
+	}
+
+	cases = Generate(matched, "", Options{MaxCases: 3})
+	if len(cases) != 3 {
+		t.Errorf("Cases should be limited to 3, got %d", len(cases))
+	}
+}
+
+func Test_GenerateBoundarySizes(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Sizes
+  version: 1.0.0
+paths:
+  /notes:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [text, tags]
+              properties:
+                text:
+                  type: string
+                  maxLength: 9223372036854775807
+                title:
+                  type: string
+                  maxLength: -1
+                code:
+                  type: string
+                  minLength: -3
+                summary:
+                  type: string
+                  minLength: 9223372036854775807
+                tags:
+                  type: array
+                  minItems: 2
+                  maxItems: 3
+                  uniqueItems: true
+                  items:
+                    type: string
+                ids:
+                  type: array
+                  maxItems: 9223372036854775807
+                  items:
+                    type: integer
+                refs:
+                  type: array
+                  minItems: 9223372036854775807
+                  items:
+                    type: integer
+      responses:
+        "201":
+          description: created
+`
+	specPath := filepath.Join(t.TempDir(), "notes.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	matched, err := document.GetMatchedOperation("/notes", "post")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	found := make(map[string]Case)
+	for _, c := range Generate(matched, "", Options{Categories: []Category{Boundary}, MaxCases: MaxCasesLimit}) {
+		found[c.Target+" "+c.Description] = c
+	}
+
+	for _, key := range []string{
+		"/body/text use string with maxLength",
+		"/body/text use string longer than maxLength",
+		"/body/ids use array longer than maxItems",
+		"/body/summary use string with minLength",
+		"/body/summary use string shorter than minLength",
+		"/body/refs use array shorter than minItems",
+	} {
+		if _, ok := found[key]; ok {
+			t.Errorf("Case %q should be skipped since bound is too large", key)
+		}
+	}
+
+	for _, key := range []string{
+		"/body/title use string with maxLength",
+		"/body/title use string longer than maxLength",
+		"/body/code use string with minLength",
+		"/body/code use string shorter than minLength",
+	} {
+		if _, ok := found[key]; ok {
+			t.Errorf("Case %q should be skipped since bound is negative", key)
+		}
+	}
+
+	if _, ok := found["/body/code use empty string"]; !ok {
+		t.Errorf("Empty string should be used when minLength is negative")
+	}
+
+	expectations := map[string]int{
+		"/body/tags use array shorter than minItems": 1,
+		"/body/tags use array longer than maxItems":  4,
+	}
+	for key, length := range expectations {
+		c, ok := found[key]
+		if !ok {
+			t.Errorf("Case %q should be generated", key)
+			continue
+		}
+
+		tags, _ := c.Request.Body.(map[string]any)["tags"].([]any)
+		if len(tags) != length {
+			t.Errorf("Case %q should have %d tags, got %v", key, length, tags)
+		}
+		for i, tag := range tags {
+			if _, ok := tag.(string); !ok || slices.Index(tags, tag) != i {
+				t.Errorf("Case %q should have unique string tags, got %v", key, tags)
+				break
+			}
+		}
+	}
+}
+
+func mustJSON(t *testing.T, value any) []byte {
+	t.Helper()
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return valueBytes
+}
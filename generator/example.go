@@ -0,0 +1,371 @@
+// Package generator will generate example value from schema of OpenAPI document,
+// example, examples and default in document will be used first,
+// value will be synthesized from type, format, enum, min/max and pattern if none of them exist
+package generator
+
+import (
+	"fmt"
+	"math"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"mcp-api-tester/validator"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// maxDepth stop generation of circular schema
+const maxDepth = 16
+
+// maxExampleSize cap string length and array items of example,
+// so huge minLength or minItems in document doesn't exhaust memory
+const maxExampleSize = 1000
+
+// Direction decide which properties will be generated,
+// readOnly is skipped in request and writeOnly is skipped in response
+type Direction int
+
+const (
+	// Request will skip readOnly properties
+	Request Direction = iota
+
+	// Response will skip writeOnly properties
+	Response
+)
+
+// Options control how example is generated
+type Options struct {
+	Direction       Direction
+	IncludeOptional bool // generate properties that are not required
+}
+
+// ExampleFromSchema return json value that match the schema
+func ExampleFromSchema(schema *base.Schema, options Options) any {
+	return example(schema, options, 0)
+}
+
+func example(schema *base.Schema, options Options, depth int) any {
+	if schema == nil || depth > maxDepth {
+		return nil
+	}
+
+	if schema.Example != nil {
+		return validator.NodeToValue(schema.Example)
+	}
+
+	if len(schema.Examples) > 0 {
+		return validator.NodeToValue(schema.Examples[0])
+	}
+
+	if schema.Default != nil {
+		return validator.NodeToValue(schema.Default)
+	}
+
+	if schema.Const != nil {
+		return validator.NodeToValue(schema.Const)
+	}
+
+	if len(schema.Enum) > 0 {
+		return validator.NodeToValue(schema.Enum[0])
+	}
+
+	if len(schema.AllOf) > 0 {
+		return exampleAllOf(schema, options, depth)
+	}
+
+	if len(schema.OneOf) > 0 {
+		return example(schema.OneOf[0].Schema(), options, depth+1)
+	}
+
+	if len(schema.AnyOf) > 0 {
+		return example(schema.AnyOf[0].Schema(), options, depth+1)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return exampleObject(schema, options, depth)
+	case "array":
+		return exampleArray(schema, options, depth)
+	case "string":
+		return ExampleString(schema)
+	case "integer":
+		return exampleNumber(schema, true)
+	case "number":
+		return exampleNumber(schema, false)
+	case "boolean":
+		return true
+	}
+
+	return nil
+}
+
+// schemaType return the first non null type, object will be guessed if schema has properties
+func schemaType(schema *base.Schema) string {
+	for _, t := range schema.Type {
+		if t != "null" {
+			return t
+		}
+	}
+
+	if schema.Properties != nil && schema.Properties.Len() > 0 {
+		return "object"
+	}
+
+	if schema.Items != nil {
+		return "array"
+	}
+
+	return ""
+}
+
+// exampleAllOf merge examples of every allOf schema into one object
+func exampleAllOf(schema *base.Schema, options Options, depth int) any {
+	merged := map[string]any{}
+	var last any
+
+	for _, proxy := range schema.AllOf {
+		value := example(proxy.Schema(), options, depth+1)
+		if object, ok := value.(map[string]any); ok {
+			for key, propertyValue := range object {
+				merged[key] = propertyValue
+			}
+			continue
+		}
+		last = value
+	}
+
+	// properties defined beside allOf
+	if schema.Properties != nil {
+		if object, ok := exampleObject(schema, options, depth).(map[string]any); ok {
+			for key, value := range object {
+				merged[key] = value
+			}
+		}
+	}
+
+	if len(merged) == 0 && last != nil {
+		return last
+	}
+
+	return merged
+}
+
+func exampleObject(schema *base.Schema, options Options, depth int) any {
+	object := map[string]any{}
+
+	if schema.Properties == nil {
+		return object
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+		name := pair.Key()
+		if !required[name] && !options.IncludeOptional {
+			continue
+		}
+
+		propertySchema := pair.Value().Schema()
+		if propertySchema == nil || skipByDirection(propertySchema, options.Direction) {
+			continue
+		}
+
+		object[name] = example(propertySchema, options, depth+1)
+	}
+
+	return object
+}
+
+// skipByDirection skip readOnly in request and writeOnly in response
+func skipByDirection(schema *base.Schema, direction Direction) bool {
+	if direction == Request {
+		return schema.ReadOnly != nil && *schema.ReadOnly
+	}
+	return schema.WriteOnly != nil && *schema.WriteOnly
+}
+
+func exampleArray(schema *base.Schema, options Options, depth int) any {
+	count := 1
+	if schema.MinItems != nil && *schema.MinItems > 1 {
+		count = int(min(*schema.MinItems, maxExampleSize))
+	}
+
+	if schema.Items == nil || !schema.Items.IsA() || schema.Items.A == nil {
+		return []any{}
+	}
+
+	itemSchema := schema.Items.A.Schema()
+	items := make([]any, 0, count)
+
+	for i := range count {
+		item := example(itemSchema, options, depth+1)
+
+		// uniqueItems need different value for every item
+		if schema.UniqueItems != nil && *schema.UniqueItems && i > 0 {
+			switch v := item.(type) {
+			case string:
+				item = fmt.Sprintf("%s%d", v, i)
+			case float64:
+				item = v + float64(i)
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func exampleNumber(schema *base.Schema, integer bool) any {
+	value := 1.0
+
+	if schema.Minimum != nil {
+		value = *schema.Minimum
+		if schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsA() && schema.ExclusiveMinimum.A {
+			value++
+		}
+	} else if schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsB() {
+		value = schema.ExclusiveMinimum.B + 1
+	} else if schema.Maximum != nil && *schema.Maximum < value {
+		value = *schema.Maximum
+		if schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsA() && schema.ExclusiveMaximum.A {
+			value--
+		}
+	}
+
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		value = math.Ceil(value / *schema.MultipleOf) * *schema.MultipleOf
+	}
+
+	if integer {
+		value = math.Ceil(value)
+	}
+
+	return value
+}
+
+// ExampleString generate string honoring format, pattern, minLength and maxLength
+func ExampleString(schema *base.Schema) string {
+	var value string
+
+	switch schema.Format {
+	case "date-time":
+		value = "2024-01-01T00:00:00Z"
+	case "date":
+		value = "2024-01-01"
+	case "time":
+		value = "00:00:00Z"
+	case "email":
+		value = "user@example.com"
+	case "uuid":
+		value = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "uri", "url":
+		value = "https://example.com"
+	case "hostname":
+		value = "example.com"
+	case "ipv4":
+		value = "192.168.0.1"
+	case "ipv6":
+		value = "2001:db8::1"
+	case "byte":
+		value = "c3RyaW5n"
+	case "binary":
+		value = "binary"
+	case "password":
+		value = "Passw0rd!"
+	default:
+		value = "string"
+	}
+
+	if schema.Pattern != "" {
+		if generated, ok := StringFromPattern(schema.Pattern); ok {
+			value = generated
+		}
+	}
+
+	// length is counted by rune like validator, negative bound is invalid and ignored
+	length := int64(utf8.RuneCountInString(value))
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		value += strings.Repeat("a", int(min(*schema.MinLength, maxExampleSize)-min(length, maxExampleSize)))
+	}
+
+	if schema.MaxLength != nil && *schema.MaxLength >= 0 {
+		if runes := []rune(value); int64(len(runes)) > *schema.MaxLength {
+			value = string(runes[:*schema.MaxLength])
+		}
+	}
+
+	return value
+}
+
+// StringFromPattern generate the shortest string that match the regular expression
+func StringFromPattern(pattern string) (string, bool) {
+	regexpTree, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+
+	var builder strings.Builder
+	if !writePattern(&builder, regexpTree.Simplify()) {
+		return "", false
+	}
+
+	return builder.String(), true
+}
+
+func writePattern(builder *strings.Builder, regexpTree *syntax.Regexp) bool {
+	switch regexpTree.Op {
+	case syntax.OpLiteral:
+		builder.WriteString(string(regexpTree.Rune))
+	case syntax.OpCharClass:
+		if len(regexpTree.Rune) == 0 {
+			return false
+		}
+		builder.WriteRune(pickRune(regexpTree.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		builder.WriteRune('a')
+	case syntax.OpCapture:
+		return writePattern(builder, regexpTree.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range regexpTree.Sub {
+			if !writePattern(builder, sub) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		return writePattern(builder, regexpTree.Sub[0])
+	case syntax.OpPlus:
+		return writePattern(builder, regexpTree.Sub[0])
+	case syntax.OpRepeat:
+		for range regexpTree.Min {
+			if !writePattern(builder, regexpTree.Sub[0]) {
+				return false
+			}
+		}
+	case syntax.OpStar, syntax.OpQuest, syntax.OpEmptyMatch,
+		syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		// zero length is the shortest match
+	default:
+		return false
+	}
+
+	return true
+}
+
+// pickRune prefer readable character in ranges of char class
+func pickRune(ranges []rune) rune {
+	for _, preferred := range []rune{'a', 'A', '0'} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= preferred && preferred <= ranges[i+1] {
+				return preferred
+			}
+		}
+	}
+	return ranges[0]
+}
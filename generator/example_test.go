@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"encoding/json"
+	"math"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+const generatorSpec = `openapi: 3.0.3
+info:
+  title: Generator
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/v1
+    variables:
+      region:
+        default: eu
+paths:
+  /users/{id}/orders:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 10
+    post:
+      parameters:
+        - name: X-Trace
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 0
+        - name: status
+          in: query
+          required: true
+          example: open
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Order"
+      responses:
+        "201":
+          description: created
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, code, amount, currency, items, createdAt, contact]
+      properties:
+        id:
+          type: string
+          readOnly: true
+        code:
+          type: string
+          pattern: "^[A-Z]{3}-[0-9]{4}$"
+        amount:
+          type: number
+          minimum: 0
+          exclusiveMinimum: true
+          multipleOf: 0.5
+        currency:
+          type: string
+          enum: [TWD, USD]
+        note:
+          type: string
+          default: none
+        items:
+          type: array
+          minItems: 2
+          uniqueItems: true
+          items:
+            type: string
+            minLength: 3
+        createdAt:
+          type: string
+          format: date-time
+        contact:
+          allOf:
+            - type: object
+              required: [email]
+              properties:
+                email:
+                  type: string
+                  format: email
+            - type: object
+              required: [phone]
+              properties:
+                phone:
+                  type: string
+                  example: "+886-2-1234"
+`
+
+func Test_StringFromPattern(t *testing.T) {
+	patterns := []string{`^[A-Z]{3}-[0-9]{4}$`, `^\d+\.\d{2}$`, `^(foo|bar)+_[a-z]?x*$`, `^[^0-9]{2}$`}
+
+	for _, pattern := range patterns {
+		value, ok := StringFromPattern(pattern)
+		if !ok {
+			t.Errorf("Pattern %q should be generated", pattern)
+			continue
+		}
+
+		if !regexp.MustCompile(pattern).MatchString(value) {
+			t.Errorf("Value %q should match pattern %q", value, pattern)
+		}
+	}
+}
+
+func Test_NewExampleRequest(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "orders.yaml")
+	if err := os.WriteFile(specPath, []byte(generatorSpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	matched, err := document.GetMatchedOperation("/users/{id}/orders", "post")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	request := NewExampleRequest(matched, document.DefaultServerURL(), Options{})
+
+	if request.URL != "https://eu.example.com/v1/users/10/orders" {
+		t.Errorf("Url should use server default and path param minimum, not %q", request.URL)
+	}
+
+	if _, ok := request.QueryParams["limit"]; ok {
+		t.Errorf("Optional query param should not be generated")
+	}
+
+	if request.QueryParams["status"] != "open" {
+		t.Errorf("Example of parameter should be used, not %q", request.QueryParams["status"])
+	}
+
+	body, ok := request.Body.(map[string]any)
+	if !ok {
+		t.Fatalf("Body should be object, not %v", request.Body)
+	}
+
+	if _, ok := body["id"]; ok {
+		t.Errorf("ReadOnly property should not be in request body")
+	}
+
+	if _, ok := body["note"]; ok {
+		t.Errorf("Optional property should not be in request body")
+	}
+
+	bodyBytes, _ := json.Marshal(body)
+
+	// Generated request should always pass its own validation
+	result := validator.ValidateRequest(matched.Operation, matched.Parameters, validator.Request{
+		PathParams:  map[string]string{"id": "10"},
+		QueryParams: request.QueryParams,
+		Headers:     request.Headers,
+		Cookies:     request.Cookies,
+		ContentType: request.ContentType,
+		Body:        bodyBytes,
+	})
+
+	// id is readOnly but required, so it is the only expected violation
+	if len(result.Violations) != 1 || result.Violations[0].Pointer != "/body/id" {
+		t.Errorf("Generated request should be valid except readOnly id, got %v", result.Violations)
+	}
+
+	request = NewExampleRequest(matched, "http://localhost:8080/", Options{IncludeOptional: true})
+
+	if request.QueryParams["limit"] != "0" {
+		t.Errorf("Optional query param should honor maximum, not %q", request.QueryParams["limit"])
+	}
+
+	if body, ok := request.Body.(map[string]any); !ok || body["note"] != "none" {
+		t.Errorf("Default of optional property should be used, got %v", request.Body)
+	}
+}
+
+func Test_ExampleBounds(t *testing.T) {
+	bound := func(value int64) *int64 { return &value }
+
+	cases := []struct {
+		name   string
+		schema *base.Schema
+		length int
+		value  string // checked if not empty
+	}{
+		{"huge minLength is capped", &base.Schema{Type: []string{"string"}, MinLength: bound(math.MaxInt64)}, maxExampleSize, ""},
+		{"negative maxLength is ignored", &base.Schema{Type: []string{"string"}, MaxLength: bound(-1)}, len("string"), "string"},
+		{"maxLength cut by rune", &base.Schema{Type: []string{"string"}, Pattern: "^中文字$", MaxLength: bound(2)}, 2, "中文"},
+		{"huge minItems is capped", &base.Schema{
+			Type:     []string{"array"},
+			MinItems: bound(math.MaxInt64),
+			Items:    &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}})},
+		}, maxExampleSize, ""},
+	}
+
+	for _, c := range cases {
+		switch value := ExampleFromSchema(c.schema, Options{}).(type) {
+		case string:
+			if length := utf8.RuneCountInString(value); length != c.length || !utf8.ValidString(value) || (c.value != "" && value != c.value) {
+				t.Errorf("%s: expected valid string of %d runes, got %q", c.name, c.length, value)
+			}
+		case []any:
+			if len(value) != c.length {
+				t.Errorf("%s: expected %d items, got %d", c.name, c.length, len(value))
+			}
+		default:
+			t.Errorf("%s: unexpected example %v", c.name, value)
+		}
+	}
+}
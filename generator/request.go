@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"fmt"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// ExampleRequest has the same shape as the param of CallNetHTTP tool,
+// so it can be send directly
+type ExampleRequest struct {
+	Method      string            `json:"method" yaml:"method"`
+	URL         string            `json:"url" yaml:"url"`
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Cookies     map[string]string `json:"cookies,omitempty" yaml:"cookies,omitempty"`
+	QueryParams map[string]string `json:"queryParams,omitempty" yaml:"queryParams,omitempty"`
+	Body        any               `json:"body,omitempty" yaml:"body,omitempty"`
+	ContentType string            `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+}
+
+// NewExampleRequest build request of operation with path params filled,
+// required query params, headers, cookies and body generated,
+// optional params will also be generated if options.IncludeOptional is true
+func NewExampleRequest(matched *openapi.MatchedOperation, baseURL string, options Options) ExampleRequest {
+	options.Direction = Request
+
+	request := ExampleRequest{
+		Method:      strings.ToUpper(matched.Method),
+		Headers:     map[string]string{},
+		Cookies:     map[string]string{},
+		QueryParams: map[string]string{},
+	}
+
+	path := matched.Path
+
+	for _, parameter := range matched.Parameters {
+		required := parameter.In == "path" || (parameter.Required != nil && *parameter.Required)
+		if !required && !options.IncludeOptional {
+			continue
+		}
+
+		value := ParameterString(ExampleFromParameter(parameter, options))
+
+		switch parameter.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+parameter.Name+"}", url.PathEscape(value))
+		case "query":
+			request.QueryParams[parameter.Name] = value
+		case "header":
+			request.Headers[parameter.Name] = value
+		case "cookie":
+			request.Cookies[parameter.Name] = value
+		}
+	}
+
+	request.URL = strings.TrimRight(baseURL, "/") + path
+
+	if matched.Operation.RequestBody != nil {
+		request.ContentType, request.Body = ExampleBody(matched.Operation.RequestBody.Content, options)
+	}
+
+	return request
+}
+
+// ExampleFromParameter use example and examples of parameter first then generate from schema
+func ExampleFromParameter(parameter *v3high.Parameter, options Options) any {
+	if parameter.Example != nil {
+		return validator.NodeToValue(parameter.Example)
+	}
+
+	if parameter.Examples != nil && parameter.Examples.Len() > 0 {
+		if first := parameter.Examples.First().Value(); first != nil && first.Value != nil {
+			return validator.NodeToValue(first.Value)
+		}
+	}
+
+	if parameter.Schema != nil {
+		return ExampleFromSchema(parameter.Schema.Schema(), options)
+	}
+
+	return "string"
+}
+
+// ExampleBody choose json media type if possible and return its content type and example,
+// form body will be encoded as string like a=1&b=2
+func ExampleBody(content *orderedmap.Map[string, *v3high.MediaType], options Options) (string, any) {
+	if content == nil || content.Len() == 0 {
+		return "", nil
+	}
+
+	contentType, mediaType := content.First().Key(), content.First().Value()
+	for pair := content.First(); pair != nil; pair = pair.Next() {
+		if validator.IsJSONMediaType(pair.Key()) {
+			contentType, mediaType = pair.Key(), pair.Value()
+			break
+		}
+	}
+
+	body := ExampleFromMediaType(mediaType, options)
+
+	if object, ok := body.(map[string]any); ok && contentType == "application/x-www-form-urlencoded" {
+		form := url.Values{}
+		for _, key := range validator.SortedKeys(object) {
+			form.Set(key, ParameterString(object[key]))
+		}
+		return contentType, form.Encode()
+	}
+
+	return contentType, body
+}
+
+// ParameterString convert example value into string that can be put in url or header,
+// array will be joined by comma which is the default style of OpenAPI
+func ParameterString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, ParameterString(item))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// ExampleFromMediaType use example and examples of media type first then generate from schema
+func ExampleFromMediaType(mediaType *v3high.MediaType, options Options) any {
+	if mediaType.Example != nil {
+		return validator.NodeToValue(mediaType.Example)
+	}
+
+	if mediaType.Examples != nil && mediaType.Examples.Len() > 0 {
+		if first := mediaType.Examples.First().Value(); first != nil && first.Value != nil {
+			return validator.NodeToValue(first.Value)
+		}
+	}
+
+	var schema *base.Schema
+	if mediaType.Schema != nil {
+		schema = mediaType.Schema.Schema()
+	}
+
+	return ExampleFromSchema(schema, options)
+}
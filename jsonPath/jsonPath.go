@@ -0,0 +1,302 @@
+// Package jsonpath will select values from decoded json by JSONPath like $.items[0].id,
+// it support child, index, slice, wildcard and recursive descent but not filter expression
+package jsonpath
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	childSegment segmentKind = iota
+	indexSegment
+	sliceSegment
+	wildcardSegment
+)
+
+// segment is one step of path like .name, [0], [1:3] or [*]
+type segment struct {
+	kind      segmentKind
+	name      string
+	index     int
+	start     *int
+	end       *int
+	recursive bool // segment after .. will search every descendant
+}
+
+// Path is a parsed JSONPath
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Parse parse path like $.items[*].id, $ can be omitted like items[0].id
+func Parse(path string) (*Path, error) {
+	raw := path
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+
+	var segments []segment
+
+	for path != "" {
+		recursive := false
+
+		switch {
+		case strings.HasPrefix(path, ".."):
+			recursive = true
+			path = path[2:]
+		case strings.HasPrefix(path, "."):
+			path = path[1:]
+		case strings.HasPrefix(path, "["):
+		default:
+			// path without $ start with name directly
+			if len(segments) > 0 {
+				return nil, fmt.Errorf("JSONPath %q is invalid near %q", raw, path)
+			}
+		}
+
+		if strings.HasPrefix(path, "[") {
+			end := closingBracket(path)
+			if end < 0 {
+				return nil, fmt.Errorf("JSONPath %q has unclosed bracket", raw)
+			}
+
+			bracketSegment, err := parseBracket(path[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("JSONPath %q is invalid, error: %w", raw, err)
+			}
+
+			bracketSegment.recursive = recursive
+			segments = append(segments, bracketSegment)
+			path = path[end+1:]
+			continue
+		}
+
+		nameEnd := strings.IndexAny(path, ".[")
+		if nameEnd < 0 {
+			nameEnd = len(path)
+		}
+
+		name := path[:nameEnd]
+		if name == "" {
+			return nil, fmt.Errorf("JSONPath %q has empty name", raw)
+		}
+
+		if name == "*" {
+			segments = append(segments, segment{kind: wildcardSegment, recursive: recursive})
+		} else {
+			segments = append(segments, segment{kind: childSegment, name: name, recursive: recursive})
+		}
+		path = path[nameEnd:]
+	}
+
+	return &Path{raw: raw, segments: segments}, nil
+}
+
+// closingBracket return index of ] that is not inside quote
+func closingBracket(path string) int {
+	var quote byte
+	for i := 1; i < len(path); i++ {
+		switch {
+		case quote != 0 && path[i] == quote:
+			quote = 0
+		case quote == 0 && (path[i] == '\'' || path[i] == '"'):
+			quote = path[i]
+		case quote == 0 && path[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseBracket(content string) (segment, error) {
+	content = strings.TrimSpace(content)
+
+	if content == "*" {
+		return segment{kind: wildcardSegment}, nil
+	}
+
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return segment{kind: childSegment, name: content[1 : len(content)-1]}, nil
+	}
+
+	if startText, endText, isSlice := strings.Cut(content, ":"); isSlice {
+		sliceSegment := segment{kind: sliceSegment}
+
+		if startText = strings.TrimSpace(startText); startText != "" {
+			start, err := strconv.Atoi(startText)
+			if err != nil {
+				return segment{}, fmt.Errorf("slice start %q is not number", startText)
+			}
+			sliceSegment.start = &start
+		}
+
+		if endText = strings.TrimSpace(endText); endText != "" {
+			end, err := strconv.Atoi(endText)
+			if err != nil {
+				return segment{}, fmt.Errorf("slice end %q is not number", endText)
+			}
+			sliceSegment.end = &end
+		}
+
+		return sliceSegment, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return segment{}, fmt.Errorf("%q is not index, quoted name, slice or *", content)
+	}
+
+	return segment{kind: indexSegment, index: index}, nil
+}
+
+// Definite return true if path can only select one value
+func (p *Path) Definite() bool {
+	for _, s := range p.segments {
+		if s.recursive || s.kind == wildcardSegment || s.kind == sliceSegment {
+			return false
+		}
+	}
+	return true
+}
+
+// String return the original path
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Query return every value selected by path in document order
+func (p *Path) Query(root any) []any {
+	nodes := []any{root}
+
+	for _, s := range p.segments {
+		var next []any
+		for _, node := range nodes {
+			if s.recursive {
+				for _, descendant := range descendants(node) {
+					next = append(next, s.apply(descendant)...)
+				}
+				continue
+			}
+			next = append(next, s.apply(node)...)
+		}
+		nodes = next
+	}
+
+	return nodes
+}
+
+// Get return the value selected by path, list of values will be return if path is not definite,
+// ok will be false if nothing selected
+func (p *Path) Get(root any) (any, bool) {
+	values := p.Query(root)
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	if p.Definite() {
+		return values[0], true
+	}
+
+	return values, true
+}
+
+// Query parse path and return every value selected by it
+func Query(root any, path string) ([]any, error) {
+	parsed, err := Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Query(root), nil
+}
+
+// Get parse path and return the value selected by it, see Path.Get
+func Get(root any, path string) (any, bool, error) {
+	parsed, err := Parse(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	value, ok := parsed.Get(root)
+	return value, ok, nil
+}
+
+func (s segment) apply(node any) []any {
+	switch s.kind {
+	case childSegment:
+		if object, ok := node.(map[string]any); ok {
+			if value, ok := object[s.name]; ok {
+				return []any{value}
+			}
+		}
+	case indexSegment:
+		if array, ok := node.([]any); ok {
+			index := s.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index >= 0 && index < len(array) {
+				return []any{array[index]}
+			}
+		}
+	case sliceSegment:
+		if array, ok := node.([]any); ok {
+			start, end := 0, len(array)
+			if s.start != nil {
+				start = normalizeIndex(*s.start, len(array))
+			}
+			if s.end != nil {
+				end = normalizeIndex(*s.end, len(array))
+			}
+			if start < end {
+				return append([]any{}, array[start:end]...)
+			}
+		}
+	case wildcardSegment:
+		return children(node)
+	}
+
+	return nil
+}
+
+// normalizeIndex convert negative index and clamp it into [0, length]
+func normalizeIndex(index int, length int) int {
+	if index < 0 {
+		index += length
+	}
+	return min(max(index, 0), length)
+}
+
+// children return values of object sorted by key or items of array
+func children(node any) []any {
+	switch v := node.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		values := make([]any, 0, len(v))
+		for _, key := range keys {
+			values = append(values, v[key])
+		}
+		return values
+	case []any:
+		return v
+	}
+	return nil
+}
+
+// descendants return node itself and every value under it
+func descendants(node any) []any {
+	nodes := []any{node}
+	for _, child := range children(node) {
+		nodes = append(nodes, descendants(child)...)
+	}
+	return nodes
+}
@@ -0,0 +1,70 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const document = `{
+  "store": {
+    "name": "shop",
+    "books": [
+      {"title": "A", "price": 10, "tags": ["x"]},
+      {"title": "B", "price": 20},
+      {"title": "C", "price": 30}
+    ],
+    "owner.name": "Alice"
+  }
+}`
+
+func Test_Query(t *testing.T) {
+	var root any
+	if err := json.Unmarshal([]byte(document), &root); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []any
+	}{
+		{"$.store.name", []any{"shop"}},
+		{"store.name", []any{"shop"}},
+		{"$['store']['owner.name']", []any{"Alice"}},
+		{"$.store.books[0].title", []any{"A"}},
+		{"$.store.books[-1].title", []any{"C"}},
+		{"$.store.books[*].price", []any{10.0, 20.0, 30.0}},
+		{"$.store.books[1:].title", []any{"B", "C"}},
+		{"$.store.books[:-2].title", []any{"A"}},
+		{"$..title", []any{"A", "B", "C"}},
+		{"$..tags[0]", []any{"x"}},
+		{"$.store.missing", nil},
+		{"$.store.books[9]", nil},
+	}
+
+	for _, test := range tests {
+		got, err := Query(root, test.path)
+		if err != nil {
+			t.Errorf("%s: %v", test.path, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: should be %v, got %v", test.path, test.want, got)
+		}
+	}
+
+	if value, ok, _ := Get(root, "$.store.books[1].price"); !ok || value != 20.0 {
+		t.Errorf("Definite path should return single value, got %v", value)
+	}
+
+	if value, ok, _ := Get(root, "$.store.books[*].title"); !ok || !reflect.DeepEqual(value, []any{"A", "B", "C"}) {
+		t.Errorf("Wildcard path should return list, got %v", value)
+	}
+
+	for _, invalid := range []string{"$.store[", "$.store[abc]", "$.store.", "$.store.books[0]title"} {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("Path %q should be invalid", invalid)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"mcp-api-tester/environment"
+	loadtester "mcp-api-tester/loadTest"
+	netclient "mcp-api-tester/net/client"
+	"mcp-api-tester/scenario"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// headerFlags collect repeated -header "Name: value"
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	return fmt.Sprint(map[string]string(h))
+}
+
+func (h headerFlags) Set(value string) error {
+	name, headerValue, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("Header %q should be like Name: value", value)
+	}
+	h[strings.TrimSpace(name)] = strings.TrimSpace(headerValue)
+	return nil
+}
+
+// runLoadCommand run load test without mcp client,
+// usage: mcp-api-tester load (-url http://localhost:8080/users [-method POST -body '{}'] | -scenario scenario.yaml) [-concurrency 10 -rate 100 -duration 30s -requests 1000] [-env-file env.yaml -env staging]
+// exit code is 1 when error rate is above -max-error-rate and 2 when load test can't be run
+func runLoadCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("load", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var rawURL string
+	flags.StringVar(&rawURL, "url", "", "Url of the request, path like /users is resolved against base url of environment")
+
+	var method string
+	flags.StringVar(&method, "method", "GET", "Http method of the request")
+
+	headers := headerFlags{}
+	flags.Var(headers, "header", "Request header like \"Authorization: Bearer abc\", can be repeated")
+
+	var body string
+	flags.StringVar(&body, "body", "", "Request body")
+
+	var contentType string
+	flags.StringVar(&contentType, "content-type", "", "Content-Type of request body")
+
+	var scenarioPath string
+	flags.StringVar(&scenarioPath, "scenario", "", "YAML or JSON file of scenario that is run as one iteration instead of -url")
+
+	var baseURL string
+	flags.StringVar(&baseURL, "base-url", "", "Base url that override the one in scenario file or environment")
+
+	var envFile string
+	flags.StringVar(&envFile, "env-file", "", "YAML or JSON file of environments that provide base url, headers, variables and auth")
+
+	var envName string
+	flags.StringVar(&envName, "env", "", "Name of environment in -env-file, default of file will be used if empty")
+
+	var concurrency int
+	flags.IntVar(&concurrency, "concurrency", 1, fmt.Sprintf("Workers sending in parallel, can't exceed %d", loadtester.MaxConcurrency))
+
+	var rate int
+	flags.IntVar(&rate, "rate", 0, fmt.Sprintf("Total iterations per second, 0 means as fast as possible, can't exceed %d", loadtester.MaxRatePerSecond))
+
+	var duration time.Duration
+	flags.DurationVar(&duration, "duration", 0, fmt.Sprintf("How long to run like 30s, default is %s and can't exceed %s", loadtester.DefaultDuration, loadtester.MaxDuration))
+
+	var requests int
+	flags.IntVar(&requests, "requests", 0, fmt.Sprintf("Stop after this many iterations, can't exceed %d", loadtester.MaxRequests))
+
+	var expectStatus string
+	flags.StringVar(&expectStatus, "expect-status", "", "Comma separated status codes that are not errors, status below 400 is expected if empty")
+
+	var maxErrorRate float64
+	flags.Float64Var(&maxErrorRate, "max-error-rate", 1, "Exit with 1 when error rate between 0 and 1 is above it")
+
+	var asJSON bool
+	flags.BoolVar(&asJSON, "json", false, "Print report as JSON instead of plain text")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if (rawURL == "") == (scenarioPath == "") {
+		fmt.Fprintln(stderr, "Either -url or -scenario is required")
+		flags.Usage()
+		return 2
+	}
+
+	options := loadtester.Options{
+		Concurrency:   concurrency,
+		RatePerSecond: rate,
+		Duration:      duration,
+		Requests:      requests,
+	}
+	for _, code := range splitList(expectStatus) {
+		status, err := strconv.Atoi(strings.TrimSpace(code))
+		if err != nil {
+			fmt.Fprintf(stderr, "Status %q of -expect-status is not a number\n", code)
+			return 2
+		}
+		options.ExpectStatus = append(options.ExpectStatus, status)
+	}
+
+	config, err := loadEnvironment(envFile, envName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	var name string
+	var target loadtester.Target
+
+	if scenarioPath != "" {
+		s, err := loadScenario(scenarioPath)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		if baseURL != "" {
+			s.BaseURL = baseURL
+		}
+		var profile *environment.Profile
+		if config != nil {
+			// file without default has no active profile unless -env is given
+			profile = config.Environments[config.Default]
+		}
+		if profile != nil {
+			s.Environment = profile
+			if s.BaseURL == "" {
+				s.BaseURL = profile.BaseURL
+			}
+			if s.Variables == nil {
+				s.Variables = map[string]any{}
+			}
+			for key, value := range profile.Variables {
+				if _, ok := s.Variables[key]; !ok {
+					s.Variables[key] = value
+				}
+			}
+		}
+
+		if err := s.Validate(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		name, target = fmt.Sprintf("scenario %q", s.Name), loadtester.ScenarioTarget(*s)
+	} else {
+		request := netclient.AIRequest{
+			Method:      strings.ToUpper(method),
+			URL:         rawURL,
+			Headers:     headers,
+			Body:        body,
+			ContentType: contentType,
+			TimeoutMs:   30 * time.Second,
+		}
+
+		if config != nil {
+			profile := config.Environments[config.Default]
+			if profile != nil && baseURL != "" {
+				// -base-url override the environment like it does for scenario
+				override := *profile
+				override.BaseURL = baseURL
+				profile = &override
+			}
+
+			if request.URL, err = profile.ResolveURL(rawURL, nil, baseURL); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 2
+			}
+			profile.Apply(&request)
+		} else if strings.HasPrefix(rawURL, "/") {
+			request.URL = strings.TrimRight(baseURL, "/") + rawURL
+		}
+
+		if strings.HasPrefix(request.URL, "/") {
+			fmt.Fprintln(stderr, "-base-url or -env-file is required when -url is a path")
+			return 2
+		}
+
+		name, target = request.Method+" "+request.URL, loadtester.RequestTarget(request)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := loadtester.Run(ctx, name, target, options)
+
+	if asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	} else {
+		printLoadReport(stdout, report)
+	}
+
+	if report.ErrorRate > maxErrorRate {
+		return 1
+	}
+	return 0
+}
+
+// loadScenario read scenario from YAML or JSON file
+func loadScenario(path string) (*scenario.Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when read scenario %q, error: %w", path, err)
+	}
+
+	var s scenario.Scenario
+	if err := yaml.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("Error happened when parse scenario %q, error: %w", path, err)
+	}
+
+	return &s, nil
+}
+
+// printLoadReport print summary and histogram with bars
+func printLoadReport(w io.Writer, report *loadtester.Report) {
+	for _, warning := range report.Warnings {
+		fmt.Fprintf(w, "warning: %s\n", warning)
+	}
+
+	fmt.Fprintf(w, "%s\n", report.Target)
+	fmt.Fprintf(w, "  %d requests in %dms, %.2f req/s, %d errors (%.2f%%)\n",
+		report.Requests, report.ElapsedMs, report.Throughput, report.Errors, report.ErrorRate*100)
+
+	statuses := make([]string, 0, len(report.Statuses))
+	for status, count := range report.Statuses {
+		statuses = append(statuses, fmt.Sprintf("%s=%d", status, count))
+	}
+	slices.Sort(statuses)
+	fmt.Fprintf(w, "  statuses: %s\n", strings.Join(statuses, " "))
+
+	latency := report.Latency
+	fmt.Fprintf(w, "  latency: min %.2fms  mean %.2fms  p50 %.2fms  p90 %.2fms  p99 %.2fms  max %.2fms\n",
+		latency.MinMs, latency.MeanMs, latency.P50Ms, latency.P90Ms, latency.P99Ms, latency.MaxMs)
+
+	var largest int64
+	for _, bucket := range report.Histogram {
+		largest = max(largest, bucket.Count)
+	}
+
+	for _, bucket := range report.Histogram {
+		bar := 0
+		if largest > 0 {
+			bar = int(bucket.Count * 40 / largest)
+		}
+		fmt.Fprintf(w, "  <= %10.0fms %8d %s\n", bucket.UpToMs, bucket.Count, strings.Repeat("#", bar))
+	}
+
+	for _, sample := range report.ErrorSamples {
+		fmt.Fprintf(w, "  error: %s\n", sample)
+	}
+}
@@ -0,0 +1,153 @@
+package loadtest
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+const (
+	// subBuckets is how many linear buckets every power of 2 is split into,
+	// so value is recorded with error below 1/halfBuckets like HdrHistogram with 2 significant digits
+	subBuckets  = 128
+	halfBuckets = subBuckets / 2
+
+	// maxShift allow values up to about 2^63 microseconds
+	maxShift = 57
+)
+
+// Histogram record latency in microseconds into log-linear buckets,
+// memory is fixed no matter how many values are recorded
+type Histogram struct {
+	counts []int64
+	total  int64
+	sum    int64
+	min    int64
+	max    int64
+}
+
+// NewHistogram create empty histogram
+func NewHistogram() *Histogram {
+	return &Histogram{
+		counts: make([]int64, subBuckets+maxShift*halfBuckets),
+		min:    math.MaxInt64,
+	}
+}
+
+// Record add one latency
+func (h *Histogram) Record(d time.Duration) {
+	value := max(d.Microseconds(), 0)
+
+	h.counts[bucketIndex(value)]++
+	h.total++
+	h.sum += value
+	h.min = min(h.min, value)
+	h.max = max(h.max, value)
+}
+
+// Count return how many values are recorded
+func (h *Histogram) Count() int64 {
+	return h.total
+}
+
+// Min return the smallest value, 0 if nothing is recorded
+func (h *Histogram) Min() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return time.Duration(h.min) * time.Microsecond
+}
+
+// Max return the largest value
+func (h *Histogram) Max() time.Duration {
+	return time.Duration(h.max) * time.Microsecond
+}
+
+// Mean return average of values
+func (h *Histogram) Mean() time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	return time.Duration(h.sum/h.total) * time.Microsecond
+}
+
+// Percentile return value that p percent of values are equal or below, p is between 0 and 100
+func (h *Histogram) Percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	target := int64(math.Ceil(p / 100 * float64(h.total)))
+	target = min(max(target, 1), h.total)
+
+	var seen int64
+	for index, count := range h.counts {
+		seen += count
+		if seen >= target {
+			return time.Duration(min(highestEquivalent(index), h.max)) * time.Microsecond
+		}
+	}
+
+	return h.Max()
+}
+
+// Bucket is count of values in (previous UpToMs, UpToMs]
+type Bucket struct {
+	UpToMs float64 `json:"upToMs" yaml:"upToMs"`
+	Count  int64   `json:"count" yaml:"count"`
+}
+
+// Buckets merge fine buckets into powers of 2 milliseconds so it is short enough to read,
+// empty buckets before the first value and after the last value are dropped
+func (h *Histogram) Buckets() []Bucket {
+	if h.total == 0 {
+		return nil
+	}
+
+	var buckets []Bucket
+	upTo := int64(1000) // 1ms in microseconds
+	var count int64
+
+	for index, c := range h.counts {
+		for highestEquivalent(index) > upTo {
+			if count > 0 || len(buckets) > 0 {
+				buckets = append(buckets, Bucket{UpToMs: float64(upTo) / 1000, Count: count})
+			}
+			count = 0
+			upTo *= 2
+		}
+		count += c
+
+		if highestEquivalent(index) >= h.max {
+			break
+		}
+	}
+
+	return append(buckets, Bucket{UpToMs: float64(upTo) / 1000, Count: count})
+}
+
+// bucketIndex put values below subBuckets into their own bucket,
+// larger values are shifted so the top 7 bits select one of halfBuckets buckets of their power of 2
+func bucketIndex(value int64) int {
+	if value < subBuckets {
+		return int(value)
+	}
+
+	shift := bits.Len64(uint64(value)) - bits.Len64(subBuckets-1)
+	shift = min(shift, maxShift)
+	sub := value >> shift
+
+	return subBuckets + (shift-1)*halfBuckets + int(sub-halfBuckets)
+}
+
+// highestEquivalent return the largest value that fall into bucket of index
+func highestEquivalent(index int) int64 {
+	if index < subBuckets {
+		return int64(index)
+	}
+
+	shift := (index-subBuckets)/halfBuckets + 1
+	sub := int64((index-subBuckets)%halfBuckets + halfBuckets)
+
+	return (sub+1)<<shift - 1
+}
@@ -0,0 +1,333 @@
+// Package loadtest will send request or scenario repeatedly at configured concurrency, rate and duration
+// and report throughput, error rate, status distribution and latency percentiles
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	netclient "mcp-api-tester/net/client"
+	"mcp-api-tester/scenario"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// hard caps so one load test can't overload target or block mcp client too long
+const (
+	MaxConcurrency   = 100
+	MaxRatePerSecond = 1000
+	MaxDuration      = 5 * time.Minute
+	MaxRequests      = 100000
+
+	// DefaultDuration is used when neither duration nor requests is given
+	DefaultDuration = 10 * time.Second
+
+	// maxErrorSamples is how many distinct error messages are kept in report
+	maxErrorSamples = 5
+)
+
+// Options control how load is generated, test stop when duration is over or requests are sent
+type Options struct {
+	Concurrency   int           `json:"concurrency" yaml:"concurrency"`                         // workers sending in parallel, default is 1
+	RatePerSecond int           `json:"ratePerSecond,omitempty" yaml:"ratePerSecond,omitempty"` // total requests per second of all workers, 0 means as fast as possible
+	Duration      time.Duration `json:"-" yaml:"-"`
+	Requests      int           `json:"requests,omitempty" yaml:"requests,omitempty"`         // total requests to send, 0 means until duration is over
+	ExpectStatus  []int         `json:"expectStatus,omitempty" yaml:"expectStatus,omitempty"` // status below 400 is expected if empty
+
+	DurationMs int64 `json:"durationMs" yaml:"durationMs"` // Duration in report
+}
+
+// Outcome is the result of one iteration
+type Outcome struct {
+	StatusCode int   // 0 if no response
+	Err        error // request failed or scenario didn't pass
+}
+
+// Target is called once per iteration
+type Target func(ctx context.Context) Outcome
+
+// RequestTarget send copy of request every iteration
+func RequestTarget(request netclient.AIRequest) Target {
+	return func(ctx context.Context) Outcome {
+		r := request
+		response, err := r.Do(ctx)
+		if err != nil {
+			return Outcome{Err: err}
+		}
+		return Outcome{StatusCode: response.StatusCode}
+	}
+}
+
+// ScenarioTarget run whole scenario every iteration, status is the one of the last step that got response
+func ScenarioTarget(s scenario.Scenario) Target {
+	return func(ctx context.Context) Outcome {
+		result := scenario.Run(ctx, s)
+
+		outcome := Outcome{}
+		for _, step := range result.Steps {
+			if step.Response != nil {
+				outcome.StatusCode = step.Response.StatusCode
+			}
+			if !step.Passed && !step.Skipped && outcome.Err == nil {
+				outcome.Err = fmt.Errorf("Step %q failed: %s", step.ID, stepFailure(step))
+			}
+		}
+		return outcome
+	}
+}
+
+func stepFailure(step scenario.StepResult) string {
+	if step.Error != "" {
+		return step.Error
+	}
+	for _, check := range step.Checks {
+		if !check.Passed {
+			return check.Name
+		}
+	}
+	return "unknown reason"
+}
+
+// Latency is summary of latency in milliseconds
+type Latency struct {
+	MinMs  float64 `json:"minMs" yaml:"minMs"`
+	MeanMs float64 `json:"meanMs" yaml:"meanMs"`
+	P50Ms  float64 `json:"p50Ms" yaml:"p50Ms"`
+	P90Ms  float64 `json:"p90Ms" yaml:"p90Ms"`
+	P99Ms  float64 `json:"p99Ms" yaml:"p99Ms"`
+	MaxMs  float64 `json:"maxMs" yaml:"maxMs"`
+}
+
+// Report is the result of load test
+type Report struct {
+	Target       string           `json:"target" yaml:"target"`
+	Options      Options          `json:"options" yaml:"options"`   // options after defaults and caps applied
+	Requests     int64            `json:"requests" yaml:"requests"` // completed iterations
+	Errors       int64            `json:"errors" yaml:"errors"`     // iterations failed or got unexpected status
+	ErrorRate    float64          `json:"errorRate" yaml:"errorRate"`
+	ElapsedMs    int64            `json:"elapsedMs" yaml:"elapsedMs"`
+	Throughput   float64          `json:"throughput" yaml:"throughput"` // completed iterations per second
+	Statuses     map[string]int64 `json:"statuses" yaml:"statuses"`     // status code or "error" to count
+	Latency      Latency          `json:"latency" yaml:"latency"`
+	Histogram    []Bucket         `json:"histogram,omitempty" yaml:"histogram,omitempty"`
+	ErrorSamples []string         `json:"errorSamples,omitempty" yaml:"errorSamples,omitempty"`
+	Warnings     []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"` // options that were capped
+}
+
+// Normalize fill defaults and cap options, warnings tell what was capped
+func (o Options) Normalize() (Options, []string) {
+	var warnings []string
+
+	if o.Concurrency <= 0 {
+		o.Concurrency = 1
+	}
+	if o.Concurrency > MaxConcurrency {
+		warnings = append(warnings, fmt.Sprintf("Concurrency %d is capped to %d", o.Concurrency, MaxConcurrency))
+		o.Concurrency = MaxConcurrency
+	}
+
+	if o.RatePerSecond < 0 {
+		o.RatePerSecond = 0
+	}
+	if o.RatePerSecond > MaxRatePerSecond {
+		warnings = append(warnings, fmt.Sprintf("Rate %d/s is capped to %d/s", o.RatePerSecond, MaxRatePerSecond))
+		o.RatePerSecond = MaxRatePerSecond
+	}
+
+	if o.Requests < 0 {
+		o.Requests = 0
+	}
+	if o.Requests > MaxRequests {
+		warnings = append(warnings, fmt.Sprintf("Requests %d is capped to %d", o.Requests, MaxRequests))
+		o.Requests = MaxRequests
+	}
+
+	if o.Duration <= 0 {
+		// duration still bound test that only give requests
+		o.Duration = DefaultDuration
+		if o.Requests > 0 {
+			o.Duration = MaxDuration
+		}
+	}
+	if o.Duration > MaxDuration {
+		warnings = append(warnings, fmt.Sprintf("Duration %s is capped to %s", o.Duration, MaxDuration))
+		o.Duration = MaxDuration
+	}
+	o.DurationMs = o.Duration.Milliseconds()
+
+	return o, warnings
+}
+
+// collector aggregate outcomes of workers
+type collector struct {
+	mu           sync.Mutex
+	histogram    *Histogram
+	statuses     map[string]int64
+	errors       int64
+	errorSamples []string
+	expectStatus []int
+}
+
+func (c *collector) record(outcome Outcome, latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.histogram.Record(latency)
+
+	status := "error"
+	if outcome.StatusCode != 0 {
+		status = strconv.Itoa(outcome.StatusCode)
+	}
+	c.statuses[status]++
+
+	err := outcome.Err
+	if err == nil && !c.expected(outcome.StatusCode) {
+		err = fmt.Errorf("Unexpected status %d", outcome.StatusCode)
+	}
+	if err == nil {
+		return
+	}
+
+	c.errors++
+	if message := err.Error(); len(c.errorSamples) < maxErrorSamples && !slices.Contains(c.errorSamples, message) {
+		c.errorSamples = append(c.errorSamples, message)
+	}
+}
+
+func (c *collector) expected(statusCode int) bool {
+	if len(c.expectStatus) > 0 {
+		return slices.Contains(c.expectStatus, statusCode)
+	}
+	return statusCode < 400
+}
+
+// Run call target by options until duration is over, requests are sent or ctx is canceled,
+// iterations interrupted by the end of test are not counted
+func Run(ctx context.Context, name string, target Target, options Options) *Report {
+	options, warnings := options.Normalize()
+
+	ctx, cancel := context.WithTimeout(ctx, options.Duration)
+	defer cancel()
+
+	c := &collector{
+		histogram:    NewHistogram(),
+		statuses:     map[string]int64{},
+		expectStatus: options.ExpectStatus,
+	}
+
+	tokens := pace(ctx, options.RatePerSecond)
+
+	var started atomic.Int64
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for range options.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				if options.Requests > 0 && started.Add(1) > int64(options.Requests) {
+					return
+				}
+
+				if tokens != nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-tokens:
+					}
+				}
+
+				if ctx.Err() != nil {
+					return
+				}
+
+				iterationStart := time.Now()
+				outcome := target(ctx)
+				latency := time.Since(iterationStart)
+
+				if ctx.Err() != nil {
+					return
+				}
+
+				c.record(outcome, latency)
+			}
+		}()
+	}
+
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	report := &Report{
+		Target:       name,
+		Options:      options,
+		Requests:     c.histogram.Count(),
+		Errors:       c.errors,
+		ElapsedMs:    elapsed.Milliseconds(),
+		Statuses:     c.statuses,
+		Histogram:    c.histogram.Buckets(),
+		ErrorSamples: c.errorSamples,
+		Warnings:     warnings,
+		Latency: Latency{
+			MinMs:  milliseconds(c.histogram.Min()),
+			MeanMs: milliseconds(c.histogram.Mean()),
+			P50Ms:  milliseconds(c.histogram.Percentile(50)),
+			P90Ms:  milliseconds(c.histogram.Percentile(90)),
+			P99Ms:  milliseconds(c.histogram.Percentile(99)),
+			MaxMs:  milliseconds(c.histogram.Max()),
+		},
+	}
+
+	if report.Requests > 0 {
+		report.ErrorRate = round(float64(report.Errors) / float64(report.Requests))
+	}
+	if elapsed > 0 {
+		report.Throughput = round(float64(report.Requests) / elapsed.Seconds())
+	}
+
+	return report
+}
+
+// pace return channel that give one token per 1/rate second, nil if rate is unlimited
+func pace(ctx context.Context, rate int) <-chan struct{} {
+	if rate <= 0 {
+		return nil
+	}
+
+	tokens := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+
+		for {
+			// the first token is given at once
+			select {
+			case <-ctx.Done():
+				return
+			case tokens <- struct{}{}:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return tokens
+}
+
+func milliseconds(d time.Duration) float64 {
+	return round(float64(d) / float64(time.Millisecond))
+}
+
+// round keep 3 decimals
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,125 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	netclient "mcp-api-tester/net/client"
+)
+
+func Test_Histogram(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	tests := []struct {
+		percentile float64
+		expected   time.Duration
+	}{
+		{50, 500 * time.Millisecond},
+		{90, 900 * time.Millisecond},
+		{99, 990 * time.Millisecond},
+		{100, 1000 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		got := h.Percentile(test.percentile)
+		// error of bucket should be below 1/64
+		if diff := got - test.expected; diff < 0 || diff > test.expected/64 {
+			t.Errorf("p%v = %v, expected about %v", test.percentile, got, test.expected)
+		}
+	}
+
+	if h.Min() != time.Millisecond || h.Max() != time.Second {
+		t.Errorf("unexpected min %v and max %v", h.Min(), h.Max())
+	}
+
+	var total int64
+	buckets := h.Buckets()
+	for _, bucket := range buckets {
+		total += bucket.Count
+	}
+	if total != 1000 {
+		t.Errorf("buckets should contain every value, got %d in %+v", total, buckets)
+	}
+	if last := buckets[len(buckets)-1]; last.UpToMs != 1024 {
+		t.Errorf("last bucket should be up to 1024ms, got %v", last.UpToMs)
+	}
+}
+
+func Test_Run_Requests(t *testing.T) {
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1)%5 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	report := Run(context.Background(), "GET /", RequestTarget(netclient.AIRequest{Method: "GET", URL: server.URL}), Options{
+		Concurrency: 4,
+		Requests:    50,
+	})
+
+	if report.Requests != 50 || count.Load() != 50 {
+		t.Fatalf("expected 50 requests, got %d recorded and %d received", report.Requests, count.Load())
+	}
+	if report.Statuses["200"] != 40 || report.Statuses["500"] != 10 || report.Errors != 10 || report.ErrorRate != 0.2 {
+		t.Errorf("unexpected statuses %v with %d errors", report.Statuses, report.Errors)
+	}
+	if report.Latency.P50Ms > report.Latency.P99Ms || report.Latency.P99Ms > report.Latency.MaxMs {
+		t.Errorf("percentiles should be ordered, got %+v", report.Latency)
+	}
+}
+
+func Test_TransportKeepWorkerConnections(t *testing.T) {
+	// idle connections above the limit are closed and dialed again, which would be counted in latency
+	if netclient.Transport.MaxIdleConnsPerHost < MaxConcurrency {
+		t.Errorf("idle connections per host %d should cover %d workers", netclient.Transport.MaxIdleConnsPerHost, MaxConcurrency)
+	}
+}
+
+func Test_Run_RateAndDuration(t *testing.T) {
+	target := func(ctx context.Context) Outcome {
+		return Outcome{Err: errors.New("boom")}
+	}
+
+	report := Run(context.Background(), "rate", target, Options{
+		Concurrency:   2,
+		RatePerSecond: 50,
+		Duration:      200 * time.Millisecond,
+	})
+
+	// about 10 iterations in 200ms at 50/s
+	if report.Requests < 5 || report.Requests > 12 {
+		t.Errorf("rate should limit iterations, got %d", report.Requests)
+	}
+	if report.Statuses["error"] != report.Requests || len(report.ErrorSamples) != 1 {
+		t.Errorf("errors should be counted once as sample, got %v %v", report.Statuses, report.ErrorSamples)
+	}
+}
+
+func Test_Options_Normalize(t *testing.T) {
+	options, warnings := Options{Concurrency: 1000, RatePerSecond: 100000, Duration: time.Hour, Requests: 1 << 30}.Normalize()
+
+	if options.Concurrency != MaxConcurrency || options.RatePerSecond != MaxRatePerSecond ||
+		options.Duration != MaxDuration || options.Requests != MaxRequests {
+		t.Errorf("options should be capped, got %+v", options)
+	}
+	if len(warnings) != 4 {
+		t.Errorf("expected 4 warnings, got %v", warnings)
+	}
+
+	options, _ = Options{}.Normalize()
+	if options.Concurrency != 1 || options.Duration != DefaultDuration {
+		t.Errorf("unexpected defaults %+v", options)
+	}
+}
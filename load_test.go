@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_runLoadCommandEnvFileWithoutDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	scenarioPath := filepath.Join(dir, "scenario.yaml")
+	envPath := filepath.Join(dir, "env.yaml")
+
+	scenarioYAML := "name: health\nbaseURL: " + server.URL + "\nsteps:\n  - method: GET\n    url: /health\n"
+	envYAML := "environments:\n  staging:\n    baseURL: http://staging.example.com\n"
+
+	if err := os.WriteFile(scenarioPath, []byte(scenarioYAML), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := os.WriteFile(envPath, []byte(envYAML), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	for _, args := range [][]string{
+		{"-scenario", scenarioPath, "-env-file", envPath, "-requests", "1"},
+		{"-url", server.URL + "/health", "-env-file", envPath, "-requests", "1"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := runLoadCommand(args, &stdout, &stderr); code != 0 {
+			t.Errorf("%v should exit with 0, got %d, stderr %s", args, code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "1 requests") {
+			t.Errorf("%v should send one request, got %s", args, stdout.String())
+		}
+	}
+}
+
+func Test_runLoadCommandBaseURLOverrideEnvironment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	envPath := filepath.Join(t.TempDir(), "env.yaml")
+	envYAML := "default: dev\nenvironments:\n  dev:\n    baseURL: http://127.0.0.1:1\n"
+	if err := os.WriteFile(envPath, []byte(envYAML), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"-url", "/health", "-env-file", envPath, "-base-url", server.URL, "-requests", "1", "-max-error-rate", "0"}
+	if code := runLoadCommand(args, &stdout, &stderr); code != 0 {
+		t.Errorf("-base-url should win over baseURL of environment, got %d, stdout %s, stderr %s", code, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), server.URL+"/health") {
+		t.Errorf("Request should be sent to -base-url, got %s", stdout.String())
+	}
+}
@@ -1,20 +1,65 @@
 // Package main can be start by sse or stdio
 //
 // use `-t sse` to start at  sse server
+//
+// use `-serve-mock ./openapi.yaml` to start a mock server of the OpenAPI file instead of mcp server
+//
+// use `run -suite suite.yaml` to run test suite without mcp client
+//
+// use `load -url http://localhost:8080/users -duration 10s` to run load test without mcp client
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"mcp-api-tester/auth"
+	"mcp-api-tester/coverage"
+	"mcp-api-tester/environment"
+	mockserver "mcp-api-tester/mockServer"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/postman"
+	"mcp-api-tester/redact"
+	"mcp-api-tester/storage"
+	callnethttp "mcp-api-tester/tools/callNetHTTP"
+	generateexamplerequest "mcp-api-tester/tools/generateExampleRequest"
+	generatefuzzinput "mcp-api-tester/tools/generateFuzzInput"
+	generatetestreport "mcp-api-tester/tools/generateTestReport"
+	getauthtoken "mcp-api-tester/tools/getAuthToken"
+	getcoverage "mcp-api-tester/tools/getCoverage"
+	getoperationsecurity "mcp-api-tester/tools/getOperationSecurity"
 	getsingleapidetail "mcp-api-tester/tools/getSingleAPIDetail"
+	gettesthistory "mcp-api-tester/tools/getTestHistory"
+	importpostmancollection "mcp-api-tester/tools/importPostmanCollection"
 	listallapifromdocument "mcp-api-tester/tools/listAllAPIFromDocument"
+	listloadeddocuments "mcp-api-tester/tools/listLoadedDocuments"
+	listpostmanrequests "mcp-api-tester/tools/listPostmanRequests"
+	listservers "mcp-api-tester/tools/listServers"
+	loadtest "mcp-api-tester/tools/loadTest"
 	readopenapidocument "mcp-api-tester/tools/readOpenAPIDocument"
+	runpostmancollection "mcp-api-tester/tools/runPostmanCollection"
+	runscenario "mcp-api-tester/tools/runScenario"
+	setauthcredential "mcp-api-tester/tools/setAuthCredential"
+	startmockserver "mcp-api-tester/tools/startMockServer"
+	stopmockserver "mcp-api-tester/tools/stopMockServer"
+	storetestresult "mcp-api-tester/tools/storeTestResult"
+	switchenvironment "mcp-api-tester/tools/switchEnvironment"
+	validateresponsewithschema "mcp-api-tester/tools/validateResponseWithSchema"
+	"os"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(runSuiteCommand(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "load" {
+		os.Exit(runLoadCommand(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var transport string
 	flag.StringVar(&transport, "t", "stdio", "Transport type, how llm connect to mcp server (stdio or sse)")
 	flag.StringVar(&transport, "transport", "stdio", "Transport type, how llm connect to mcp server (stdio or sse)")
@@ -23,15 +68,107 @@ func main() {
 
 	flag.StringVar(&port, "p", "8000", "The port that sse server will listen to")
 	flag.StringVar(&port, "sse-port", "8000", "The port that sse server will listen to")
+
+	var mockSpec string
+	flag.StringVar(&mockSpec, "serve-mock", "", "Path or url of OpenAPI file, start a mock server of it instead of mcp server")
+
+	var mockPort string
+	flag.StringVar(&mockPort, "mock-port", "4010", "The port that mock server will listen to")
+
+	var mockValidate bool
+	flag.BoolVar(&mockValidate, "mock-validate", false, "Mock server will response 400 if request doesn't match OpenAPI file")
+
+	var dataDir string
+	flag.StringVar(&dataDir, "data-dir", storage.DefaultDir(), "The directory that test results will be saved to")
+
+	var envFile string
+	flag.StringVar(&envFile, "env-file", "", "YAML or JSON file of environments like dev or staging that provide base url, headers, variables and auth")
+
+	var envName string
+	flag.StringVar(&envName, "env", "", "Name of environment in -env-file that is active at start, default of file will be used if empty")
+
+	var noRedact bool
+	flag.BoolVar(&noRedact, "no-redact", false, "Keep secrets like Authorization header, cookies and password fields in tool results, history and logs, only for debugging")
+
+	var redactHeaders string
+	flag.StringVar(&redactHeaders, "redact-headers", "", "Comma separated header name patterns like x-*-key that are redacted besides the default ones")
+
+	var redactCookies string
+	flag.StringVar(&redactCookies, "redact-cookies", "", "Comma separated cookie name patterns that are redacted besides the default ones, every cookie is redacted by default")
+
+	var redactFields string
+	flag.StringVar(&redactFields, "redact-fields", "", "Comma separated json field and query param name patterns like *pin that are redacted besides the default ones")
 	flag.Parse()
 
-	err := run(transport, port)
+	redact.Default = newRedactor(noRedact, redactHeaders, redactCookies, redactFields)
+	log.SetOutput(redact.Default.Writer(os.Stderr))
+
+	storage.Results = storage.NewStore(dataDir)
+
+	config, err := loadEnvironment(envFile, envName)
+	if err != nil {
+		log.Fatalf("Environment error: %v", err)
+	}
+	environment.Environments.SetDefault(config)
+
+	if mockSpec != "" {
+		err = serveMock(mockSpec, mockPort, mockValidate)
+	} else {
+		err = run(transport, port)
+	}
 
 	if err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
 
+// newRedactor add patterns of flags to the default ones, nothing is redacted if disabled
+func newRedactor(disabled bool, headers string, cookies string, fields string) *redact.Redactor {
+	if disabled {
+		log.Printf("Redaction is disabled, secrets will be shown in tool results, history and logs")
+		return redact.Disabled()
+	}
+
+	config := redact.DefaultConfig()
+	config.Headers = append(config.Headers, splitList(headers)...)
+	config.Cookies = append(config.Cookies, splitList(cookies)...)
+	config.Fields = append(config.Fields, splitList(fields)...)
+
+	return redact.New(config)
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// loadEnvironment read environment file and make profile of name active,
+// nil will be return if path is empty
+func loadEnvironment(path string, name string) (*environment.Config, error) {
+	if path == "" {
+		if name != "" {
+			return nil, fmt.Errorf("-env-file is required when -env is given")
+		}
+		return nil, nil
+	}
+
+	config, err := environment.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		if _, ok := config.Environments[name]; !ok {
+			return nil, fmt.Errorf("Environment %q is not defined in %q", name, path)
+		}
+		config.Default = name
+	}
+
+	return config, nil
+}
+
 // run will start server base on transport type
 func run(transport string, port string) error {
 	srv := newMCPServer()
@@ -55,16 +192,65 @@ func run(transport string, port string) error {
 	}
 }
 
+// serveMock will start mock server of OpenAPI file and block until it stopped
+func serveMock(specPath string, port string, validate bool) error {
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		return err
+	}
+
+	mockServer := mockserver.New(document, mockserver.Options{
+		ValidateRequest: validate,
+	})
+
+	log.Printf("Mock server of %q listening on http://localhost:%s", specPath, port)
+	return mockServer.ListenAndServe(fmt.Sprintf(":%s", port))
+}
+
 // newMCPServer will return MCPServer that register all tools
 func newMCPServer() *server.MCPServer {
+	hooks := &server.Hooks{}
+
+	// Documents, collections, environments, credentials, coverage and mock server of a session should be released when client disconnect
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		openapi.Documents.RemoveSession(session.SessionID())
+		postman.Collections.RemoveSession(session.SessionID())
+		auth.Credentials.RemoveSession(session.SessionID())
+		environment.Environments.RemoveSession(session.SessionID())
+		coverage.Trackers.RemoveSession(session.SessionID())
+		_, _ = mockserver.Servers.Stop(context.Background(), session.SessionID())
+	})
+
 	srv := server.NewMCPServer(
 		"mcp-api-tester",
 		"0.0.1",
+		server.WithHooks(hooks),
 	)
 
 	readopenapidocument.AddReadOpenAPIDocumentTool(srv)
 	listallapifromdocument.AddListAllAPIFromDocumentTool(srv)
+	listloadeddocuments.AddListLoadedDocumentsTool(srv)
+	listservers.AddListServersTool(srv)
 	getsingleapidetail.AddGetSingleAPIDetailTool(srv)
+	callnethttp.AddCallNetHTTPTool(srv)
+	generateexamplerequest.AddGenerateExampleRequestTool(srv)
+	generatefuzzinput.AddGenerateFuzzInputTool(srv)
+	validateresponsewithschema.AddValidateResponseWithSchemaTool(srv)
+	startmockserver.AddStartMockServerTool(srv)
+	stopmockserver.AddStopMockServerTool(srv)
+	storetestresult.AddStoreTestResultTool(srv)
+	gettesthistory.AddGetTestHistoryTool(srv)
+	runscenario.AddRunScenarioTool(srv)
+	loadtest.AddLoadTestTool(srv)
+	importpostmancollection.AddImportPostmanCollectionTool(srv)
+	listpostmanrequests.AddListPostmanRequestsTool(srv)
+	runpostmancollection.AddRunPostmanCollectionTool(srv)
+	generatetestreport.AddGenerateTestReportTool(srv)
+	getcoverage.AddGetCoverageTool(srv)
+	switchenvironment.AddSwitchEnvironmentTool(srv)
+	setauthcredential.AddSetAuthCredentialTool(srv)
+	getoperationsecurity.AddGetOperationSecurityTool(srv)
+	getauthtoken.AddGetAuthTokenTool(srv)
 
 	return srv
 }
@@ -0,0 +1,220 @@
+// Package mockserver will start a local http server from loaded OpenAPI document,
+// every path and method in document will response example or schema-synthesized body
+// with documented status code and content type
+package mockserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+)
+
+// Options control behavior of mock server
+type Options struct {
+	ValidateRequest bool // response 400 with violations if request doesn't match the document
+}
+
+// Override replace the generated response of one operation
+type Override struct {
+	StatusCode  int               `json:"statusCode" yaml:"statusCode"`
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	ContentType string            `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Body        any               `json:"body,omitempty" yaml:"body,omitempty"` // string will be written as it is, others will be encoded as json
+}
+
+// Server is the mock server of one OpenAPI document
+type Server struct {
+	document *openapi.OpenAPI
+	options  Options
+
+	mu         sync.RWMutex
+	overrides  map[string]Override // key is like "GET /users/{id}"
+	httpServer *http.Server
+	url        string
+}
+
+// New create mock server of document, call Start to listen or use it as http.Handler directly
+func New(document *openapi.OpenAPI, options Options) *Server {
+	return &Server{
+		document:  document,
+		options:   options,
+		overrides: make(map[string]Override),
+	}
+}
+
+// SetOverride make operation of templated path and method always response override
+func (s *Server) SetOverride(path string, method string, override Override) error {
+	if _, err := s.document.GetMatchedOperation(path, method); err != nil {
+		return err
+	}
+
+	if override.StatusCode == 0 {
+		override.StatusCode = http.StatusOK
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.overrides[overrideKey(method, path)] = override
+	return nil
+}
+
+// Start listen on addr like :4010 or 127.0.0.1:0 and serve in background, url of server will be return
+func (s *Server) Start(addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("Error happened when listen mock server on %q, error: %w", addr, err)
+	}
+
+	httpServer := &http.Server{Handler: s}
+
+	s.mu.Lock()
+	s.httpServer = httpServer
+	s.url = "http://" + listenerHost(listener.Addr())
+	s.mu.Unlock()
+
+	go func() {
+		_ = httpServer.Serve(listener)
+	}()
+
+	return s.url, nil
+}
+
+// ListenAndServe listen on addr and block until server closed
+func (s *Server) ListenAndServe(addr string) error {
+	httpServer := &http.Server{Addr: addr, Handler: s}
+
+	s.mu.Lock()
+	s.httpServer = httpServer
+	s.mu.Unlock()
+
+	err := httpServer.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// URL return url of server started by Start
+func (s *Server) URL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.url
+}
+
+// Close shutdown the server gracefully
+func (s *Server) Close(ctx context.Context) error {
+	s.mu.RLock()
+	httpServer := s.httpServer
+	s.mu.RUnlock()
+
+	if httpServer == nil {
+		return nil
+	}
+
+	return httpServer.Shutdown(ctx)
+}
+
+// ServeHTTP route request to operation in document and write the mock response
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	matched, err := s.document.FindOperation(r.Method, r.URL.Path)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]any{"message": err.Error()})
+		return
+	}
+
+	if s.options.ValidateRequest {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+			return
+		}
+
+		result := validator.ValidateRequest(matched.Operation, matched.Parameters, toValidatorRequest(r, matched.PathParams, body))
+		if !result.Valid {
+			writeJSON(w, http.StatusBadRequest, map[string]any{
+				"message":    "Request doesn't match OpenAPI document",
+				"violations": result.Violations,
+			})
+			return
+		}
+	}
+
+	s.mu.RLock()
+	override, ok := s.overrides[overrideKey(matched.Method, matched.Path)]
+	s.mu.RUnlock()
+
+	var response *Response
+	if ok {
+		response = responseFromOverride(override)
+	} else {
+		response = NewResponse(matched.Operation, preferredCode(r), r.Header.Get("Accept"))
+	}
+
+	response.Write(w)
+}
+
+// toValidatorRequest collect params of http request, only the first value of each param will be used
+func toValidatorRequest(r *http.Request, pathParams map[string]string, body []byte) validator.Request {
+	request := validator.Request{
+		PathParams:  pathParams,
+		QueryParams: map[string]string{},
+		Headers:     map[string]string{},
+		Cookies:     map[string]string{},
+		ContentType: r.Header.Get("Content-Type"),
+		Body:        body,
+	}
+
+	for key, values := range r.URL.Query() {
+		request.QueryParams[key] = strings.Join(values, ",")
+	}
+
+	for key := range r.Header {
+		request.Headers[key] = r.Header.Get(key)
+	}
+
+	for _, cookie := range r.Cookies() {
+		request.Cookies[cookie.Name] = cookie.Value
+	}
+
+	return request
+}
+
+// preferredCode read status code from header like `Prefer: code=404`
+func preferredCode(r *http.Request) string {
+	for _, preference := range strings.Split(r.Header.Get("Prefer"), ",") {
+		if code, ok := strings.CutPrefix(strings.TrimSpace(preference), "code="); ok {
+			return code
+		}
+	}
+	return ""
+}
+
+func overrideKey(method string, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// listenerHost replace unspecified ip like [::] with localhost so url can be called directly
+func listenerHost(addr net.Addr) string {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok || !tcpAddr.IP.IsUnspecified() {
+		return addr.String()
+	}
+	return fmt.Sprintf("localhost:%d", tcpAddr.Port)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
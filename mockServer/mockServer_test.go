@@ -0,0 +1,222 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"io"
+	openapi "mcp-api-tester/openAPI"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const mockSpec = `openapi: 3.0.3
+info:
+  title: Mock
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "404":
+          description: not found
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  message:
+                    type: string
+                    example: user not found
+        "200":
+          description: ok
+          headers:
+            X-Rate-Limit:
+              schema:
+                type: integer
+                example: 100
+          content:
+            text/plain:
+              example: plain user
+            application/json:
+              schema:
+                type: object
+                required: [id, name]
+                properties:
+                  id:
+                    type: integer
+                    minimum: 1
+                  name:
+                    type: string
+                    example: Alice
+                  password:
+                    type: string
+                    writeOnly: true
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        "201":
+          description: created
+`
+
+func newTestServer(t *testing.T, options Options) (*Server, *httptest.Server) {
+	t.Helper()
+
+	specPath := filepath.Join(t.TempDir(), "mock.yaml")
+	if err := os.WriteFile(specPath, []byte(mockSpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mockServer := New(document, options)
+	httpServer := httptest.NewServer(mockServer)
+	t.Cleanup(httpServer.Close)
+
+	return mockServer, httpServer
+}
+
+func send(t *testing.T, method string, url string, headers map[string]string, body string) (*http.Response, string) {
+	t.Helper()
+
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, _ := io.ReadAll(response.Body)
+	return response, string(responseBody)
+}
+
+func Test_ServeHTTP(t *testing.T) {
+	_, httpServer := newTestServer(t, Options{})
+
+	response, body := send(t, http.MethodGet, httpServer.URL+"/v1/users/1", nil, "")
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Lowest 2xx should be used, got %d", response.StatusCode)
+	}
+
+	if response.Header.Get("Content-Type") != "application/json" || response.Header.Get("X-Rate-Limit") != "100" {
+		t.Errorf("Json and documented headers should be response, got %v", response.Header)
+	}
+
+	var user map[string]any
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		t.Fatalf("Body should be json, got %q", body)
+	}
+
+	if user["name"] != "Alice" || user["id"] != 1.0 {
+		t.Errorf("Body should be generated from schema, got %v", user)
+	}
+
+	if _, ok := user["password"]; ok {
+		t.Errorf("WriteOnly property should not be response")
+	}
+
+	response, body = send(t, http.MethodGet, httpServer.URL+"/users/1", map[string]string{"Accept": "text/plain"}, "")
+	if response.Header.Get("Content-Type") != "text/plain" || body != "plain user" {
+		t.Errorf("Accept header should choose text/plain, got %q %q", response.Header.Get("Content-Type"), body)
+	}
+
+	response, body = send(t, http.MethodGet, httpServer.URL+"/users/1", map[string]string{"Prefer": "code=404"}, "")
+	if response.StatusCode != http.StatusNotFound || !strings.Contains(body, "user not found") {
+		t.Errorf("Prefer header should choose 404, got %d %q", response.StatusCode, body)
+	}
+
+	response, body = send(t, http.MethodPost, httpServer.URL+"/users", nil, "{}")
+	if response.StatusCode != http.StatusCreated || body != "" {
+		t.Errorf("Response without content should be empty, got %d %q", response.StatusCode, body)
+	}
+
+	response, _ = send(t, http.MethodDelete, httpServer.URL+"/users", nil, "")
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("Undocumented operation should be 404, got %d", response.StatusCode)
+	}
+}
+
+func Test_ServeHTTPValidateAndOverride(t *testing.T) {
+	mockServer, httpServer := newTestServer(t, Options{ValidateRequest: true})
+
+	response, body := send(t, http.MethodGet, httpServer.URL+"/users/abc", nil, "")
+	if response.StatusCode != http.StatusBadRequest || !strings.Contains(body, "/path/id") {
+		t.Errorf("Invalid path param should be rejected, got %d %q", response.StatusCode, body)
+	}
+
+	response, body = send(t, http.MethodPost, httpServer.URL+"/users", map[string]string{"Content-Type": "application/json"}, `{"age": 1}`)
+	if response.StatusCode != http.StatusBadRequest || !strings.Contains(body, "/body/name") {
+		t.Errorf("Missing required property should be rejected, got %d %q", response.StatusCode, body)
+	}
+
+	if err := mockServer.SetOverride("/users", "post", Override{StatusCode: 409, Body: "conflict", ContentType: "text/plain"}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	response, body = send(t, http.MethodPost, httpServer.URL+"/users", map[string]string{"Content-Type": "application/json"}, `{"name": "Bob"}`)
+	if response.StatusCode != 409 || body != "conflict" || response.Header.Get("Content-Type") != "text/plain" {
+		t.Errorf("Override should be response, got %d %q", response.StatusCode, body)
+	}
+
+	if err := mockServer.SetOverride("/missing", "get", Override{}); err == nil {
+		t.Errorf("Override of undocumented operation should fail")
+	}
+}
+
+func Test_Start(t *testing.T) {
+	mockServer, _ := newTestServer(t, Options{})
+
+	url, err := mockServer.Start("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	registry := NewRegistry()
+	if err := registry.Store(t.Context(), "session", mockServer); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	response, _ := send(t, http.MethodGet, url+"/users/1", nil, "")
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Started server should response, got %d", response.StatusCode)
+	}
+
+	if stopped, err := registry.Stop(t.Context(), "session"); !stopped || err != nil {
+		t.Errorf("Server should be stopped, got %v %v", stopped, err)
+	}
+
+	if _, err := http.Get(url + "/users/1"); err == nil {
+		t.Errorf("Stopped server should not response")
+	}
+}
@@ -0,0 +1,50 @@
+package mockserver
+
+import (
+	"context"
+	"sync"
+)
+
+// Servers keep mock servers started by each mcp session
+var Servers = NewRegistry()
+
+// Registry store running mock server of each mcp session, one session can only run one mock server
+type Registry struct {
+	mu      sync.Mutex
+	servers map[string]*Server
+}
+
+// NewRegistry create an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		servers: make(map[string]*Server),
+	}
+}
+
+// Store save server of session, the previous server of session will be closed
+func (r *Registry) Store(ctx context.Context, sessionID string, server *Server) error {
+	r.mu.Lock()
+	previous := r.servers[sessionID]
+	r.servers[sessionID] = server
+	r.mu.Unlock()
+
+	if previous == nil {
+		return nil
+	}
+
+	return previous.Close(ctx)
+}
+
+// Stop close and remove server of session, false will be return if session has no server
+func (r *Registry) Stop(ctx context.Context, sessionID string) (bool, error) {
+	r.mu.Lock()
+	server, ok := r.servers[sessionID]
+	delete(r.servers, sessionID)
+	r.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	return true, server.Close(ctx)
+}
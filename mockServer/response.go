@@ -0,0 +1,211 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"mcp-api-tester/generator"
+	"mcp-api-tester/validator"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Response is what mock server will write back
+type Response struct {
+	StatusCode  int
+	Headers     map[string]string
+	ContentType string
+	Body        any
+}
+
+// NewResponse generate response of operation, preferredCode choose documented status code like 404,
+// the lowest 2xx will be used if it is empty, accept choose content type when response has many
+func NewResponse(operation *v3high.Operation, preferredCode string, accept string) *Response {
+	statusCode, response := pickResponse(operation, preferredCode)
+
+	mockResponse := &Response{
+		StatusCode: statusCode,
+		Headers:    map[string]string{},
+	}
+
+	if response == nil {
+		return mockResponse
+	}
+
+	options := generator.Options{
+		Direction:       generator.Response,
+		IncludeOptional: true,
+	}
+
+	if response.Headers != nil {
+		for pair := response.Headers.First(); pair != nil; pair = pair.Next() {
+			header := pair.Value()
+			if header == nil || strings.EqualFold(pair.Key(), "Content-Type") {
+				continue
+			}
+
+			var value any
+			if header.Example != nil {
+				value = validator.NodeToValue(header.Example)
+			} else if header.Schema != nil {
+				value = generator.ExampleFromSchema(header.Schema.Schema(), options)
+			}
+
+			mockResponse.Headers[pair.Key()] = generator.ParameterString(value)
+		}
+	}
+
+	contentType, mediaType := pickMediaType(response, accept)
+	if mediaType != nil {
+		mockResponse.ContentType = contentType
+		mockResponse.Body = generator.ExampleFromMediaType(mediaType, options)
+	}
+
+	return mockResponse
+}
+
+// Write write status code, headers and body into w
+func (r *Response) Write(w http.ResponseWriter) {
+	for key, value := range r.Headers {
+		w.Header().Set(key, value)
+	}
+
+	var body []byte
+	switch v := r.Body.(type) {
+	case nil:
+	case string:
+		body = []byte(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+			return
+		}
+		body = encoded
+	}
+
+	if body != nil {
+		contentType := r.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		w.Header().Set("Content-Type", contentType)
+	}
+
+	w.WriteHeader(r.StatusCode)
+	_, _ = w.Write(body)
+}
+
+func responseFromOverride(override Override) *Response {
+	headers := make(map[string]string, len(override.Headers))
+	for key, value := range override.Headers {
+		headers[key] = value
+	}
+
+	return &Response{
+		StatusCode:  override.StatusCode,
+		Headers:     headers,
+		ContentType: override.ContentType,
+		Body:        override.Body,
+	}
+}
+
+// pickResponse return status code and response, preferred code is used if documented,
+// otherwise the lowest 2xx, then default, then the first documented response
+func pickResponse(operation *v3high.Operation, preferredCode string) (int, *v3high.Response) {
+	if operation.Responses == nil {
+		return http.StatusOK, nil
+	}
+
+	if preferredCode != "" {
+		if code, err := strconv.Atoi(preferredCode); err == nil {
+			if _, response := validator.FindResponse(operation, code); response != nil {
+				return code, response
+			}
+		}
+	}
+
+	bestCode := 0
+	var best *v3high.Response
+	firstCode := 0
+	var first *v3high.Response
+
+	if operation.Responses.Codes != nil {
+		for pair := operation.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+			code, ok := statusCodeOf(pair.Key())
+			if !ok {
+				continue
+			}
+
+			if first == nil {
+				firstCode, first = code, pair.Value()
+			}
+
+			if code >= 200 && code < 300 && (best == nil || code < bestCode) {
+				bestCode, best = code, pair.Value()
+			}
+		}
+	}
+
+	switch {
+	case best != nil:
+		return bestCode, best
+	case operation.Responses.Default != nil:
+		return http.StatusOK, operation.Responses.Default
+	case first != nil:
+		return firstCode, first
+	}
+
+	return http.StatusOK, nil
+}
+
+// statusCodeOf convert documented code like 201 or 2XX into status code
+func statusCodeOf(code string) (int, bool) {
+	if len(code) == 3 && strings.EqualFold(code[1:], "XX") {
+		code = code[:1] + "00"
+	}
+
+	statusCode, err := strconv.Atoi(code)
+	if err != nil {
+		return 0, false
+	}
+
+	return statusCode, true
+}
+
+// pickMediaType choose content type by accept header, json is preferred if nothing accepted
+func pickMediaType(response *v3high.Response, accept string) (string, *v3high.MediaType) {
+	if response.Content == nil || response.Content.Len() == 0 {
+		return "", nil
+	}
+
+	for _, acceptType := range strings.Split(accept, ",") {
+		acceptType, _, _ = strings.Cut(acceptType, ";")
+		acceptType = strings.ToLower(strings.TrimSpace(acceptType))
+
+		if acceptType == "" || acceptType == "*/*" {
+			continue
+		}
+
+		acceptMain, acceptSub, _ := strings.Cut(acceptType, "/")
+
+		for pair := response.Content.First(); pair != nil; pair = pair.Next() {
+			contentType, _, _ := strings.Cut(strings.ToLower(pair.Key()), ";")
+			contentMain, _, _ := strings.Cut(contentType, "/")
+
+			if contentType == acceptType || (acceptSub == "*" && contentMain == acceptMain) {
+				return pair.Key(), pair.Value()
+			}
+		}
+	}
+
+	for pair := response.Content.First(); pair != nil; pair = pair.Next() {
+		if validator.IsJSONMediaType(pair.Key()) {
+			return pair.Key(), pair.Value()
+		}
+	}
+
+	first := response.Content.First()
+	return first.Key(), first.Value()
+}
@@ -2,13 +2,16 @@
 package netclient
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 )
 
-// AIRequest is a struct that represents an AI request. It includes all the necessary fields to make a POST request to an AI API. The `SendRequest` method sends the request and returns the response or an error.
+// AIRequest is a struct that represents an AI request. It includes all the necessary fields to make a request to an API. The `SendRequest` method sends the request and returns the response or an error.
 type AIRequest struct {
 	Method      string
 	URL         string
@@ -17,22 +20,69 @@ type AIRequest struct {
 	QueryParams map[string]string //
 	Body        string
 	ContentType string
-	TimeoutMs   time.Duration
-	MaxRetries  int
-	RetryDelay  time.Duration
+	TimeoutMs   time.Duration // timeout of each attempt include reading body
+	MaxRetries  int           // retries after the first attempt, 0 means send only once
+	RetryDelay  time.Duration // base delay of exponential backoff, DefaultRetryDelay if 0
+
+	// RetryStatuses are status codes that can be retried, DefaultRetryStatuses if empty,
+	// request that is not idempotent is only retried when response has Retry-After
+	RetryStatuses []int
 }
 
-// SendRequest sends the AI request and returns the response or an error.
-func (r *AIRequest) SendRequest() (*http.Response, error) {
+// SendRequest sends the AI request and returns the response or an error,
+// ctx cancel the request and backoff between retries
+func (r *AIRequest) SendRequest(ctx context.Context) (*http.Response, error) {
+	resp, _, _, err := r.send(ctx)
+	return resp, err
+}
+
+// send return response of the last attempt, how many attempts are made and tracer of the last attempt
+func (r *AIRequest) send(ctx context.Context) (*http.Response, int, *tracer, error) {
+	targetURL, err := r.targetURL()
+	if err != nil {
+		return nil, 0, nil, err
+	}
 
 	client := &http.Client{
-		Timeout: r.TimeoutMs,
+		Transport: Transport,
+		Timeout:   r.TimeoutMs,
 	}
 
-	// rawUrl can have query params in it. We need to parse them and add them to the request URL.
+	policy := r.retryPolicy()
+	idempotent := isIdempotent(r.Method, r.Headers)
+
+	for attempt := 0; ; attempt++ {
+		trace, traceCtx := newTracer(ctx)
+
+		req, err := r.newRequest(traceCtx, targetURL)
+		if err != nil {
+			return nil, attempt, nil, err
+		}
+
+		resp, err := client.Do(req)
+
+		retryAfter, retry := policy.shouldRetry(idempotent, resp, err)
+		if !retry || attempt >= r.MaxRetries || ctx.Err() != nil {
+			return resp, attempt + 1, trace, err
+		}
+
+		// drain body so connection can be reused by next attempt
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+			resp.Body.Close()
+		}
+
+		if err := sleep(ctx, policy.delay(attempt, retryAfter)); err != nil {
+			return nil, attempt + 1, nil, fmt.Errorf("Request to %q is canceled while waiting to retry, error: %w", r.URL, err)
+		}
+	}
+}
+
+// targetURL merge query params into url, rawUrl can have query params in it
+func (r *AIRequest) targetURL() (string, error) {
 	parsedURL, err := url.Parse(r.URL)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	query := parsedURL.Query()
@@ -42,10 +92,12 @@ func (r *AIRequest) SendRequest() (*http.Response, error) {
 
 	parsedURL.RawQuery = query.Encode()
 
-	targetURL := parsedURL.String()
-
-	req, err := http.NewRequest(r.Method, targetURL, strings.NewReader(r.Body))
+	return parsedURL.String(), nil
+}
 
+// newRequest build request with fresh body, body reader is consumed by every attempt
+func (r *AIRequest) newRequest(ctx context.Context, targetURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, r.Method, targetURL, strings.NewReader(r.Body))
 	if err != nil {
 		return nil, err
 	}
@@ -63,16 +115,5 @@ func (r *AIRequest) SendRequest() (*http.Response, error) {
 		req.AddCookie(&http.Cookie{Name: key, Value: value})
 	}
 
-	// Send the request and get the response
-	var resp *http.Response
-
-	for range r.MaxRetries {
-		resp, err = client.Do(req)
-		if err == nil {
-			break
-		}
-		time.Sleep(r.RetryDelay)
-	}
-
-	return resp, err
+	return req, nil
 }
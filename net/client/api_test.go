@@ -0,0 +1,158 @@
+package netclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer response 503 for the first failures requests, every body received is recorded
+func newFlakyServer(t *testing.T, failures int32, retryAfter string) (*httptest.Server, *atomic.Int32, chan string) {
+	t.Helper()
+
+	var count atomic.Int32
+	bodies := make(chan string, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+
+		if count.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &count, bodies
+}
+
+func Test_Do_ZeroRetries(t *testing.T) {
+	server, count, _ := newFlakyServer(t, 0, "")
+
+	response, err := (&AIRequest{Method: "GET", URL: server.URL}).Do(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || count.Load() != 1 || response.Retries != 0 {
+		t.Errorf("expected one attempt with 200, got status %d after %d attempts", response.StatusCode, count.Load())
+	}
+}
+
+func Test_Do_RetryIdempotent(t *testing.T) {
+	server, count, bodies := newFlakyServer(t, 2, "")
+
+	request := &AIRequest{Method: "PUT", URL: server.URL, Body: `{"name":"tom"}`, MaxRetries: 3, RetryDelay: time.Millisecond}
+
+	response, err := request.Do(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || count.Load() != 3 || response.Retries != 2 {
+		t.Errorf("expected 200 after 3 attempts, got status %d after %d attempts", response.StatusCode, count.Load())
+	}
+
+	for range 3 {
+		if body := <-bodies; body != `{"name":"tom"}` {
+			t.Errorf("body should be sent on every attempt, got %q", body)
+		}
+	}
+}
+
+func Test_Do_NotIdempotent(t *testing.T) {
+	server, count, _ := newFlakyServer(t, 1, "")
+
+	response, err := (&AIRequest{Method: "POST", URL: server.URL, MaxRetries: 3, RetryDelay: time.Millisecond}).Do(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.StatusCode != http.StatusServiceUnavailable || count.Load() != 1 {
+		t.Errorf("POST without Retry-After should not be retried, got status %d after %d attempts", response.StatusCode, count.Load())
+	}
+
+	server, count, _ = newFlakyServer(t, 1, "0")
+
+	response, err = (&AIRequest{Method: "POST", URL: server.URL, MaxRetries: 3, RetryDelay: time.Millisecond}).Do(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || count.Load() != 2 {
+		t.Errorf("POST with Retry-After should be retried, got status %d after %d attempts", response.StatusCode, count.Load())
+	}
+}
+
+func Test_Do_Canceled(t *testing.T) {
+	server, count, _ := newFlakyServer(t, 10, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := (&AIRequest{Method: "GET", URL: server.URL, MaxRetries: 5, RetryDelay: time.Second}).Do(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if count.Load() != 1 {
+		t.Errorf("no retry should be made after ctx is done, got %d attempts", count.Load())
+	}
+}
+
+func Test_retryPolicy_delay(t *testing.T) {
+	policy := retryPolicy{baseDelay: 100 * time.Millisecond, now: time.Now, jitter: func() float64 { return 0.999 }}
+
+	if delay := policy.delay(3, 0); delay < 400*time.Millisecond || delay > 800*time.Millisecond {
+		t.Errorf("delay of 4th attempt should be between 400ms and 800ms, got %v", delay)
+	}
+	if delay := policy.delay(40, 0); delay > MaxRetryDelay {
+		t.Errorf("delay should be capped by MaxRetryDelay, got %v", delay)
+	}
+	if delay := policy.delay(0, 2*time.Second); delay != 2*time.Second {
+		t.Errorf("Retry-After should be used, got %v", delay)
+	}
+
+	if _, ok := policy.retryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); !ok {
+		t.Errorf("Retry-After of http date should be parsed")
+	}
+}
+
+func Test_Do_Timing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	request := &AIRequest{Method: "GET", URL: server.URL}
+
+	first, err := request.Do(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timing := first.Timing
+	if timing == nil {
+		t.Fatalf("timing should be returned")
+	}
+	if timing.ConnectionReused || timing.ConnectMs <= 0 || timing.RemoteAddr == "" {
+		t.Errorf("first request should open new connection, got %+v", timing)
+	}
+	if timing.WaitMs < 20 || timing.TTFBMs < timing.WaitMs || timing.TotalMs < timing.TTFBMs {
+		t.Errorf("unexpected wait and ttfb %+v", timing)
+	}
+
+	second, err := request.Do(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !second.Timing.ConnectionReused || second.Timing.ConnectMs != 0 {
+		t.Errorf("second request should reuse connection of shared transport, got %+v", second.Timing)
+	}
+}
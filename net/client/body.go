@@ -0,0 +1,23 @@
+package netclient
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// EncodeBody keep string body as it is and marshal other type into json,
+// content type will be application/json for json body and empty for string body
+func EncodeBody(body any) (string, string, error) {
+	switch b := body.(type) {
+	case nil:
+		return "", "", nil
+	case string:
+		return b, "", nil
+	default:
+		bodyBytes, err := json.Marshal(b)
+		if err != nil {
+			return "", "", fmt.Errorf("Convert body to json failed, error: %w", err)
+		}
+		return string(bodyBytes), "application/json", nil
+	}
+}
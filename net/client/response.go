@@ -0,0 +1,81 @@
+package netclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AIResponse is the simplified version of http.Response that can be return to LLM
+type AIResponse struct {
+	StatusCode int               `json:"statusCode"`
+	Status     string            `json:"status"`
+	Headers    map[string]string `json:"headers"`
+	Body       any               `json:"body"` // decoded json if possible, otherwise plain text
+	Size       int               `json:"size"` // size of body in bytes
+	ElapsedMs  int64             `json:"elapsedMs"`
+	Retries    int               `json:"retries,omitempty"` // how many times request is retried before this response
+	Timing     *Timing           `json:"timing,omitempty"`  // breakdown of the last attempt
+	RawBody    []byte            `json:"-"`
+}
+
+// Do sends the AI request, read the whole body and return simplified response
+func (r *AIRequest) Do(ctx context.Context) (*AIResponse, error) {
+	start := time.Now()
+
+	resp, attempts, trace, err := r.send(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when read response body from %q, error: %w", r.URL, err)
+	}
+
+	trace.finish()
+
+	response := NewAIResponse(resp, rawBody, time.Since(start))
+	response.Retries = attempts - 1
+	response.Timing = trace.Timing()
+
+	return response, nil
+}
+
+// NewAIResponse convert http.Response and its already read body into AIResponse
+func NewAIResponse(resp *http.Response, rawBody []byte, elapsed time.Duration) *AIResponse {
+	headers := make(map[string]string, len(resp.Header))
+	for key, values := range resp.Header {
+		headers[key] = strings.Join(values, ", ")
+	}
+
+	return &AIResponse{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    headers,
+		Body:       DecodeBody(rawBody),
+		Size:       len(rawBody),
+		ElapsedMs:  elapsed.Milliseconds(),
+		RawBody:    rawBody,
+	}
+}
+
+// DecodeBody will try to decode body as json, plain text will be return if it is not json
+func DecodeBody(rawBody []byte) any {
+	if len(rawBody) == 0 {
+		return nil
+	}
+
+	var decoded any
+	if err := json.Unmarshal(rawBody, &decoded); err == nil {
+		return decoded
+	}
+
+	return string(rawBody)
+}
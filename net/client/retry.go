@@ -0,0 +1,151 @@
+package netclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRetryDelay is the base delay of backoff, it is doubled on every retry
+	DefaultRetryDelay = 200 * time.Millisecond
+
+	// MaxRetryDelay cap delay of backoff and Retry-After, response with longer Retry-After is not retried
+	MaxRetryDelay = 30 * time.Second
+
+	// maxDrainSize is how many bytes of discarded response are read so connection can be reused
+	maxDrainSize = 64 << 10
+)
+
+// DefaultRetryStatuses are too many requests and service unavailable
+var DefaultRetryStatuses = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+// Transport is shared by every request so connections are kept alive between calls,
+// idle connections per host should cover every worker of load test so they are not re-dialed between iterations
+var Transport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          200,
+	MaxIdleConnsPerHost:   100,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// retryPolicy decide whether attempt is retried and how long to wait
+type retryPolicy struct {
+	statuses  []int
+	baseDelay time.Duration
+	now       func() time.Time
+	jitter    func() float64 // return number in [0, 1)
+}
+
+func (r *AIRequest) retryPolicy() retryPolicy {
+	policy := retryPolicy{
+		statuses:  r.RetryStatuses,
+		baseDelay: r.RetryDelay,
+		now:       time.Now,
+		jitter:    rand.Float64,
+	}
+	if len(policy.statuses) == 0 {
+		policy.statuses = DefaultRetryStatuses
+	}
+	if policy.baseDelay <= 0 {
+		policy.baseDelay = DefaultRetryDelay
+	}
+	return policy
+}
+
+// shouldRetry retry transport error and retry statuses of idempotent request,
+// request that is not idempotent may have been processed so it is only retried when server ask with Retry-After
+func (p retryPolicy) shouldRetry(idempotent bool, resp *http.Response, err error) (time.Duration, bool) {
+	if err != nil {
+		// timeout of client and canceled ctx should not be retried
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		return 0, idempotent
+	}
+
+	if !slices.Contains(p.statuses, resp.StatusCode) {
+		return 0, false
+	}
+
+	retryAfter, ok := p.retryAfter(resp.Header.Get("Retry-After"))
+	if ok && retryAfter > MaxRetryDelay {
+		return 0, false
+	}
+
+	return retryAfter, idempotent || ok
+}
+
+// retryAfter parse Retry-After as seconds or http date
+func (p retryPolicy) retryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(p.now()), 0), true
+	}
+
+	return 0, false
+}
+
+// delay is the Retry-After of server if given, otherwise exponential backoff with jitter between half and full of it
+func (p retryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	backoff := MaxRetryDelay
+	if attempt < 16 {
+		backoff = min(p.baseDelay<<attempt, MaxRetryDelay)
+	}
+
+	return backoff/2 + time.Duration(p.jitter()*float64(backoff/2))
+}
+
+// isIdempotent follow RFC 9110, request with Idempotency-Key header is also treated as idempotent
+func isIdempotent(method string, headers map[string]string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	for key := range headers {
+		if http.CanonicalHeaderKey(key) == "Idempotency-Key" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sleep wait for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package netclient
+
+import (
+	"context"
+	"crypto/tls"
+	"math"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing is where time of the last attempt went, phases that didn't happen like dns of reused connection are 0
+type Timing struct {
+	DNSMs      float64 `json:"dnsMs" yaml:"dnsMs"`           // resolve host
+	ConnectMs  float64 `json:"connectMs" yaml:"connectMs"`   // tcp connect
+	TLSMs      float64 `json:"tlsMs" yaml:"tlsMs"`           // tls handshake
+	WaitMs     float64 `json:"waitMs" yaml:"waitMs"`         // from request written to first byte, time spent by server
+	TTFBMs     float64 `json:"ttfbMs" yaml:"ttfbMs"`         // from start of attempt to first byte
+	TransferMs float64 `json:"transferMs" yaml:"transferMs"` // from first byte to body fully read
+	TotalMs    float64 `json:"totalMs" yaml:"totalMs"`       // from start of attempt to body fully read
+
+	ConnectionReused bool    `json:"connectionReused" yaml:"connectionReused"`
+	ConnectionIdleMs float64 `json:"connectionIdleMs,omitempty" yaml:"connectionIdleMs,omitempty"` // how long reused connection was idle
+	RemoteAddr       string  `json:"remoteAddr,omitempty" yaml:"remoteAddr,omitempty"`
+	TLSVersion       string  `json:"tlsVersion,omitempty" yaml:"tlsVersion,omitempty"`
+}
+
+// tracer record time of each phase by httptrace, hooks can be called from other goroutines
+type tracer struct {
+	mu sync.Mutex
+
+	start, dnsStart, dnsDone, connectStart, connectDone, tlsStart, tlsDone time.Time
+	wroteRequest, firstByte, done                                          time.Time
+
+	reused     bool
+	idle       time.Duration
+	remoteAddr string
+	tlsVersion string
+}
+
+// newTracer return ctx that trace request sent with it
+func newTracer(ctx context.Context) (*tracer, context.Context) {
+	t := &tracer{start: time.Now()}
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart: func(string, string) {
+			// dial can happen for more than one address, the first start is kept
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_ string, _ string, err error) {
+			if err == nil {
+				t.mark(&t.connectDone)
+			}
+		},
+		TLSHandshakeStart: func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			t.mark(&t.tlsDone)
+			if err == nil {
+				t.mu.Lock()
+				t.tlsVersion = tls.VersionName(state.Version)
+				t.mu.Unlock()
+			}
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.reused = info.Reused
+			t.idle = info.IdleTime
+			if info.Conn != nil {
+				t.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}
+
+	return t, httptrace.WithClientTrace(ctx, trace)
+}
+
+func (t *tracer) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*at = time.Now()
+}
+
+// finish is called after body is read
+func (t *tracer) finish() {
+	t.mark(&t.done)
+}
+
+// Timing convert recorded time into durations
+func (t *tracer) Timing() *Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := &Timing{
+		DNSMs:            between(t.dnsStart, t.dnsDone),
+		ConnectMs:        between(t.connectStart, t.connectDone),
+		TLSMs:            between(t.tlsStart, t.tlsDone),
+		WaitMs:           between(t.wroteRequest, t.firstByte),
+		TTFBMs:           between(t.start, t.firstByte),
+		TransferMs:       between(t.firstByte, t.done),
+		TotalMs:          between(t.start, t.done),
+		ConnectionReused: t.reused,
+		RemoteAddr:       t.remoteAddr,
+		TLSVersion:       t.tlsVersion,
+	}
+
+	if t.reused {
+		timing.ConnectionIdleMs = milliseconds(t.idle)
+	}
+
+	return timing
+}
+
+// between return milliseconds from start to end, 0 if any of them didn't happen
+func between(start time.Time, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return milliseconds(end.Sub(start))
+}
+
+// milliseconds keep 3 decimals so phases shorter than 1ms like dns of localhost are not 0
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
@@ -5,5 +5,6 @@ package openapi
 
 // This instance.go is where to put variable that will be constantly reuse
 
-// OpenAPIPointer Point to the OpenAPI instance that initialized by ReadFromFile
-var OpenAPIPointer *OpenAPI
+// Documents store every OpenAPI instance that initialized by ReadFromFile,
+// keyed by mcp session id so different clients will not overwrite each other
+var Documents = NewRegistry()
@@ -10,12 +10,22 @@ import (
 )
 
 // OpenAPI contain all field that need to be used in openapi package
-// Only implement OpenAPI v3
+// Swagger 2.0 document will be converted into OpenAPI v3 model, so only v3 need to be implemented
 type OpenAPI struct {
+	source     string // path or url that document was read from
 	document   libopenapi.Document
 	docModelV3 *libopenapi.DocumentModel[v3high.Document]
 }
 
+// DocumentInfo is the summary of a loaded document
+type DocumentInfo struct {
+	Source         string `json:"source" yaml:"source"`
+	Title          string `json:"title" yaml:"title"`
+	Version        string `json:"version" yaml:"version"`
+	SpecVersion    string `json:"specVersion" yaml:"specVersion"`
+	OperationCount int    `json:"operationCount" yaml:"operationCount"`
+}
+
 // SimplifyAPI only list basic information about api
 type SimplifyAPI struct {
 	Description string           `json:"description" yaml:"description"`
@@ -65,6 +75,34 @@ func (o *OpenAPI) ListAllAPIFromDocument() []SimplifyAPI {
 	return simplifyAPIs
 }
 
+// Model return OpenAPI v3 model of document, swagger 2.0 document has already been converted
+func (o *OpenAPI) Model() *v3high.Document {
+	return &o.docModelV3.Model
+}
+
+// Info return title, version and how many operations are in the document
+func (o *OpenAPI) Info() DocumentInfo {
+	info := DocumentInfo{
+		Source:      o.source,
+		SpecVersion: o.document.GetVersion(),
+	}
+
+	model := o.docModelV3.Model
+
+	if model.Info != nil {
+		info.Title = model.Info.Title
+		info.Version = model.Info.Version
+	}
+
+	if model.Paths != nil {
+		for pathPairs := model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+			info.OperationCount += pathPairs.Value().GetOperations().Len()
+		}
+	}
+
+	return info
+}
+
 // GetOneAPIByPath will return *v3high.Operation by giving path and method
 func (o *OpenAPI) GetOneAPIByPath(path string, method string) (*v3high.Operation, error) {
 	methodLower := strings.ToLower(method)
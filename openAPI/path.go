@@ -0,0 +1,280 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// MatchedOperation is the operation that matched by method and real request path
+type MatchedOperation struct {
+	Path       string              `json:"path" yaml:"path"`     // templated path in document like /users/{id}
+	Method     string              `json:"method" yaml:"method"` // lower case method like get
+	PathParams map[string]string   `json:"pathParams" yaml:"pathParams"`
+	Operation  *v3high.Operation   `json:"-" yaml:"-"`
+	Parameters []*v3high.Parameter `json:"-" yaml:"-"` // path item and operation parameters merged
+}
+
+// MatchPath match real path like /users/1 with templated path like /users/{id},
+// the values of path params will be return when matched
+func MatchPath(template string, requestPath string) (map[string]string, bool) {
+	templateSegments := splitPath(template)
+	requestSegments := splitPath(requestPath)
+
+	if len(templateSegments) != len(requestSegments) {
+		return nil, false
+	}
+
+	pathParams := make(map[string]string)
+
+	for i, templateSegment := range templateSegments {
+		requestSegment, err := url.PathUnescape(requestSegments[i])
+		if err != nil {
+			requestSegment = requestSegments[i]
+		}
+
+		if !strings.Contains(templateSegment, "{") {
+			if templateSegment != requestSegment {
+				return nil, false
+			}
+			continue
+		}
+
+		if !matchSegment(templateSegment, requestSegment, pathParams) {
+			return nil, false
+		}
+	}
+
+	return pathParams, true
+}
+
+// matchSegment match segment that contain params like {id} or {name}.{format}
+func matchSegment(templateSegment string, requestSegment string, pathParams map[string]string) bool {
+	for templateSegment != "" {
+		start := strings.Index(templateSegment, "{")
+		if start < 0 {
+			return templateSegment == requestSegment
+		}
+
+		// literal before param should be the same
+		if !strings.HasPrefix(requestSegment, templateSegment[:start]) {
+			return false
+		}
+		requestSegment = requestSegment[start:]
+
+		end := strings.Index(templateSegment, "}")
+		if end < start {
+			return false
+		}
+		name := templateSegment[start+1 : end]
+		templateSegment = templateSegment[end+1:]
+
+		// param take everything until next literal
+		nextLiteral := templateSegment
+		if next := strings.Index(templateSegment, "{"); next >= 0 {
+			nextLiteral = templateSegment[:next]
+		}
+
+		valueEnd := len(requestSegment)
+		if nextLiteral != "" {
+			valueEnd = strings.LastIndex(requestSegment, nextLiteral)
+			if valueEnd < 0 {
+				return false
+			}
+		}
+
+		if valueEnd == 0 {
+			return false
+		}
+
+		pathParams[name] = requestSegment[:valueEnd]
+		requestSegment = requestSegment[valueEnd:]
+	}
+
+	return requestSegment == ""
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// countTemplateParams is used to prefer literal path like /users/me over /users/{id}
+func countTemplateParams(template string) int {
+	return strings.Count(template, "{")
+}
+
+// FindOperation find operation by method and real request path like /users/1,
+// path prefix of servers like /v1 will be stripped if path doesn't match directly
+func (o *OpenAPI) FindOperation(method string, requestPath string) (*MatchedOperation, error) {
+	methodLower := strings.ToLower(method)
+
+	for _, candidate := range o.pathCandidates(requestPath) {
+		var best *MatchedOperation
+
+		for pathPairs := o.docModelV3.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+			pathParams, ok := MatchPath(pathPairs.Key(), candidate)
+			if !ok {
+				continue
+			}
+
+			operation := pathPairs.Value().GetOperations().GetOrZero(methodLower)
+			if operation == nil {
+				continue
+			}
+
+			if best == nil || countTemplateParams(pathPairs.Key()) < countTemplateParams(best.Path) {
+				best = &MatchedOperation{
+					Path:       pathPairs.Key(),
+					Method:     methodLower,
+					PathParams: pathParams,
+					Operation:  operation,
+					Parameters: MergeParameters(pathPairs.Value().Parameters, operation.Parameters),
+				}
+			}
+		}
+
+		if best != nil {
+			return best, nil
+		}
+	}
+
+	return nil, fmt.Errorf("No operation in OpenAPI file match %s %q", strings.ToUpper(methodLower), requestPath)
+}
+
+// FindPath find templated path like /users/{id} by real request path of any method,
+// literal path is preferred and path prefix of servers like /v1 will be stripped if path doesn't match directly
+func (o *OpenAPI) FindPath(requestPath string) (string, bool) {
+	for _, candidate := range o.pathCandidates(requestPath) {
+		best := ""
+
+		for pathPairs := o.docModelV3.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+			if _, ok := MatchPath(pathPairs.Key(), candidate); !ok {
+				continue
+			}
+
+			if best == "" || countTemplateParams(pathPairs.Key()) < countTemplateParams(best) {
+				best = pathPairs.Key()
+			}
+		}
+
+		if best != "" {
+			return best, true
+		}
+	}
+
+	return "", false
+}
+
+// MatchRequestPath match real request path with templated path like /users/{id} and return values of path params,
+// path prefix of servers like /v1 will be stripped if path doesn't match directly
+func (o *OpenAPI) MatchRequestPath(template string, requestPath string) (map[string]string, bool) {
+	for _, candidate := range o.pathCandidates(requestPath) {
+		if pathParams, ok := MatchPath(template, candidate); ok {
+			return pathParams, true
+		}
+	}
+	return nil, false
+}
+
+// pathCandidates return request path and the path without base path of every server
+func (o *OpenAPI) pathCandidates(requestPath string) []string {
+	candidates := []string{requestPath}
+	for _, basePath := range o.serverBasePaths() {
+		// base path /v1 should not be stripped from /v10/users
+		if stripped, ok := strings.CutPrefix(requestPath, basePath); ok && basePath != "" && (stripped == "" || strings.HasPrefix(stripped, "/")) {
+			candidates = append(candidates, stripped)
+		}
+	}
+	return candidates
+}
+
+// GetMatchedOperation return operation by templated path and method with merged parameters
+func (o *OpenAPI) GetMatchedOperation(path string, method string) (*MatchedOperation, error) {
+	operation, err := o.GetOneAPIByPath(path, method)
+	if err != nil {
+		return nil, err
+	}
+
+	pathItem := o.docModelV3.Model.Paths.PathItems.GetOrZero(path)
+
+	return &MatchedOperation{
+		Path:       path,
+		Method:     strings.ToLower(method),
+		PathParams: map[string]string{},
+		Operation:  operation,
+		Parameters: MergeParameters(pathItem.Parameters, operation.Parameters),
+	}, nil
+}
+
+// FindOperationByID return operation that has operationId with merged parameters
+func (o *OpenAPI) FindOperationByID(operationID string) (*MatchedOperation, error) {
+	for pathPairs := o.docModelV3.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		for operationPairs := pathPairs.Value().GetOperations().First(); operationPairs != nil; operationPairs = operationPairs.Next() {
+			operation := operationPairs.Value()
+			if operation.OperationId != operationID {
+				continue
+			}
+
+			return &MatchedOperation{
+				Path:       pathPairs.Key(),
+				Method:     operationPairs.Key(),
+				PathParams: map[string]string{},
+				Operation:  operation,
+				Parameters: MergeParameters(pathPairs.Value().Parameters, operation.Parameters),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("No operation in OpenAPI file has operationId %q", operationID)
+}
+
+// MergeParameters let operation parameters override path item parameters with same name and location
+func MergeParameters(pathParameters []*v3high.Parameter, operationParameters []*v3high.Parameter) []*v3high.Parameter {
+	merged := make([]*v3high.Parameter, 0, len(pathParameters)+len(operationParameters))
+	overridden := make(map[string]bool, len(operationParameters))
+
+	for _, parameter := range operationParameters {
+		overridden[parameter.In+":"+parameter.Name] = true
+	}
+
+	for _, parameter := range pathParameters {
+		if !overridden[parameter.In+":"+parameter.Name] {
+			merged = append(merged, parameter)
+		}
+	}
+
+	return append(merged, operationParameters...)
+}
+
+// serverBasePaths return path part of every server url like /v1 at all levels,
+// variables are replaced by their default
+func (o *OpenAPI) serverBasePaths() []string {
+	var basePaths []string
+
+	servers := o.DocumentServers()
+	for pathPairs := o.docModelV3.Model.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		servers = append(servers, o.toServers(pathPairs.Value().Servers, ServerLevelPath)...)
+		for operationPairs := pathPairs.Value().GetOperations().First(); operationPairs != nil; operationPairs = operationPairs.Next() {
+			servers = append(servers, o.toServers(operationPairs.Value().Servers, ServerLevelOperation)...)
+		}
+	}
+
+	for _, server := range servers {
+		resolved, _ := server.Resolve(nil)
+
+		serverURL, err := url.Parse(resolved)
+		if err != nil {
+			continue
+		}
+
+		basePath := strings.TrimRight(serverURL.Path, "/")
+		if basePath != "" && !strings.Contains(basePath, "{") && !slices.Contains(basePaths, basePath) {
+			basePaths = append(basePaths, basePath)
+		}
+	}
+
+	return basePaths
+}
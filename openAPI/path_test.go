@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+func Test_MatchPath(t *testing.T) {
+	testCases := []struct {
+		template    string
+		requestPath string
+		matched     bool
+		pathParams  map[string]string
+	}{
+		{"/users/{id}", "/users/1", true, map[string]string{"id": "1"}},
+		{"/users/{id}", "/users/1/", true, map[string]string{"id": "1"}},
+		{"/users/{id}", "/users", false, nil},
+		{"/users/{id}/orders/{orderId}", "/users/a%20b/orders/9", true, map[string]string{"id": "a b", "orderId": "9"}},
+		{"/files/{name}.{ext}", "/files/report.v2.pdf", true, map[string]string{"name": "report.v2", "ext": "pdf"}},
+		{"/users/me", "/users/1", false, nil},
+	}
+
+	for _, testCase := range testCases {
+		pathParams, matched := MatchPath(testCase.template, testCase.requestPath)
+
+		if matched != testCase.matched {
+			t.Errorf("%q match %q should be %v", testCase.template, testCase.requestPath, testCase.matched)
+			continue
+		}
+
+		for name, value := range testCase.pathParams {
+			if pathParams[name] != value {
+				t.Errorf("Path param %q of %q should be %q, not %q", name, testCase.requestPath, value, pathParams[name])
+			}
+		}
+	}
+}
+
+func Test_FindOperation(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getUser
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+  /users/me:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+	document, err := newOpenAPI([]byte(spec), datamodel.NewDocumentConfiguration(), "users.yaml")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	matched, err := document.FindOperation("GET", "/v1/users/42")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if matched.Path != "/users/{id}" || matched.PathParams["id"] != "42" || len(matched.Parameters) != 2 {
+		t.Errorf("Server base path should be stripped and parameters merged, got %+v", matched)
+	}
+
+	matched, err = document.FindOperation("get", "/users/me")
+	if err != nil || matched.Path != "/users/me" {
+		t.Errorf("Literal path should be preferred over templated path, got %+v, error: %v", matched, err)
+	}
+
+	if _, err = document.FindOperation("delete", "/users/42"); err == nil {
+		t.Errorf("Undocumented method should not be matched")
+	}
+
+	if path, ok := document.FindPath("/v1/users/42"); !ok || path != "/users/{id}" {
+		t.Errorf("Path of any method should be found, got %q", path)
+	}
+
+	if path, ok := document.FindPath("/users/me"); !ok || path != "/users/me" {
+		t.Errorf("Literal path should be preferred over templated path, got %q", path)
+	}
+
+	for _, requestPath := range []string{"/v10/users/42", "/v1users/42"} {
+		if path, ok := document.FindPath(requestPath); ok {
+			t.Errorf("Base path should only be stripped at segment boundary, %q matched %q", requestPath, path)
+		}
+	}
+
+	if _, ok := document.FindPath("/orders/1"); ok {
+		t.Errorf("Undocumented path should not be found")
+	}
+
+	if pathParams, ok := document.MatchRequestPath("/users/{id}", "/v1/users/me"); !ok || pathParams["id"] != "me" {
+		t.Errorf("Request path should match given template after base path is stripped, got %v", pathParams)
+	}
+
+	matched, err = document.FindOperationByID("getUser")
+	if err != nil || matched.Path != "/users/{id}" || matched.Method != "get" || len(matched.Parameters) != 2 {
+		t.Errorf("Operation should be found by operationId, got %+v, error: %v", matched, err)
+	}
+
+	if _, err = document.FindOperationByID("deleteUser"); err == nil {
+		t.Errorf("Unknown operationId should not be found")
+	}
+}
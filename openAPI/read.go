@@ -2,30 +2,138 @@ package openapi
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
 )
 
-// ReadFromPath will read openAPI file from given path
+// DefaultRemoteTimeout will be used when RemoteOption.Timeout is not provided
+const DefaultRemoteTimeout = 30 * time.Second
+
+// RemoteOption is the option that used when read openAPI file from http(s) url
+type RemoteOption struct {
+	Headers map[string]string // like Authorization, will only be send to the same host of document
+	Timeout time.Duration
+}
+
+// IsRemotePath check if path is http or https url
+func IsRemotePath(path string) bool {
+	lowerPath := strings.ToLower(path)
+	return strings.HasPrefix(lowerPath, "http://") || strings.HasPrefix(lowerPath, "https://")
+}
+
+// ReadFromPath will read openAPI file from given path,
+// http(s) url will be read by ReadFromURL without any option
 func ReadFromPath(path string) (*OpenAPI, error) {
+	if IsRemotePath(path) {
+		return ReadFromURL(path, RemoteOption{})
+	}
+
 	openAPIFileBinary, err := os.ReadFile(path)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error happened read open api file from path: %q, error: %w", path, err)
 	}
 
-	document, err := libopenapi.NewDocument(openAPIFileBinary)
+	return newOpenAPI(openAPIFileBinary, datamodel.NewDocumentConfiguration(), path)
+}
+
+// ReadFromURL will fetch openAPI file from given http(s) url,
+// remote $ref will be resolved relative to that url
+func ReadFromURL(rawURL string, option RemoteOption) (*OpenAPI, error) {
+	documentURL, err := url.Parse(rawURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when parse open api url: %q, error: %w", rawURL, err)
+	}
+
+	timeout := option.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRemoteTimeout
+	}
+
+	client := &http.Client{
+		Timeout: timeout,
+	}
+
+	remoteURLHandler := func(refURL string) (*http.Response, error) {
+		return fetchRemote(client, refURL, documentURL.Host, option.Headers)
+	}
+
+	resp, err := remoteURLHandler(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened fetch open api file from url: %q, error: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Error happened fetch open api file from url: %q, status: %s", rawURL, resp.Status)
+	}
+
+	openAPIFileBinary, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened read open api file from url: %q, error: %w", rawURL, err)
+	}
+
+	// BaseURL should not point to a file, so use the folder of document
+	baseURL := *documentURL
+	baseURL.Path = path.Dir(documentURL.Path)
+	baseURL.RawQuery = ""
+	baseURL.Fragment = ""
+
+	config := datamodel.NewDocumentConfiguration()
+	config.BaseURL = &baseURL
+	config.AllowRemoteReferences = true
+	config.RemoteURLHandler = remoteURLHandler
+
+	return newOpenAPI(openAPIFileBinary, config, rawURL)
+}
+
+// fetchRemote send GET request to url, headers will only be attached
+// when url has the same host as the document to avoid leaking credentials
+func fetchRemote(client *http.Client, rawURL string, documentHost string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL.Host == documentHost {
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+	}
+
+	return client.Do(req)
+}
+
+// newOpenAPI build OpenAPI from binary of openAPI file
+func newOpenAPI(openAPIFileBinary []byte, config *datamodel.DocumentConfiguration, path string) (*OpenAPI, error) {
+	document, err := libopenapi.NewDocumentWithConfiguration(openAPIFileBinary, config)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error happened when convert openAPI Binary to document from path: %q, error: %w", path, err)
 	}
 
-	docModel, errs := document.BuildV3Model()
+	var docModel *libopenapi.DocumentModel[v3high.Document]
+	var errs []error
+
+	// Swagger 2.0 will be converted to v3, so other functions behave identically
+	if document.GetSpecInfo().SpecFormat == datamodel.OAS2 {
+		docModel, errs = buildV3ModelFromSwagger(document)
+	} else {
+		docModel, errs = document.BuildV3Model()
+	}
 
 	if len(errs) > 0 {
-		errorMessages := make([]string, len(errs))
+		errorMessages := make([]string, 0, len(errs))
 
 		for _, err := range errs {
 			errorMessages = append(errorMessages, err.Error())
@@ -34,11 +142,23 @@ func ReadFromPath(path string) (*OpenAPI, error) {
 		return nil, fmt.Errorf("Error happened when build openAPI, errors: %s", strings.Join(errorMessages, ", "))
 	}
 
-	// Check openApi/instance
-	OpenAPIPointer = &OpenAPI{
+	return &OpenAPI{
+		source:     path,
 		document:   document,
 		docModelV3: docModel,
+	}, nil
+}
+
+// buildV3ModelFromSwagger build swagger 2.0 model and convert it into v3 model
+func buildV3ModelFromSwagger(document libopenapi.Document) (*libopenapi.DocumentModel[v3high.Document], []error) {
+	swaggerModel, errs := document.BuildV2Model()
+
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
-	return OpenAPIPointer, nil
+	return &libopenapi.DocumentModel[v3high.Document]{
+		Model: *convertSwaggerToV3(&swaggerModel.Model),
+		Index: swaggerModel.Index,
+	}, nil
 }
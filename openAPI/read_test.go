@@ -2,17 +2,19 @@ package openapi
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
 func Test_ReadFromPath(t *testing.T) {
-	_, err := ReadFromPath("/home/tinymurky/Desktop/ISunFa.yaml")
+	openAPI, err := ReadFromPath("/home/tinymurky/Desktop/ISunFa.yaml")
 
 	if err != nil {
-		t.Errorf("%v", err)
+		t.Fatalf("%v", err)
 	}
 
-	simplifyAPIs := OpenAPIPointer.ListAllAPIFromDocument()
+	simplifyAPIs := openAPI.ListAllAPIFromDocument()
 
 	_, err = json.Marshal(simplifyAPIs)
 
@@ -20,3 +22,66 @@ func Test_ReadFromPath(t *testing.T) {
 		t.Errorf("%v", err)
 	}
 }
+
+func Test_ReadFromURL(t *testing.T) {
+	const rootSpec = `openapi: 3.0.3
+info:
+  title: Remote
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      summary: Get user
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "schemas/user.yaml"
+`
+	const userSchema = `type: object
+required:
+  - id
+properties:
+  id:
+    type: string
+`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/specs/openapi.yaml":
+			_, _ = w.Write([]byte(rootSpec))
+		case "/specs/schemas/user.yaml":
+			_, _ = w.Write([]byte(userSchema))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	openAPI, err := ReadFromURL(srv.URL+"/specs/openapi.yaml", RemoteOption{
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	operation, err := openAPI.GetOneAPIByPath("/users/{id}", "get")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	mediaType := operation.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json")
+	schema := mediaType.Schema.Schema()
+
+	if schema == nil || len(schema.Required) != 1 || schema.Required[0] != "id" {
+		t.Errorf("Remote $ref should be resolved to user schema, not %v", schema)
+	}
+}
@@ -0,0 +1,119 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultAlias is the alias of document when user doesn't choose one
+const DefaultAlias = "default"
+
+// ErrDocumentNotLoaded will be return when session hasn't read any OpenAPI file
+var ErrDocumentNotLoaded = errors.New("OpenAPI file hasn't been loaded")
+
+// Registry store OpenAPI documents for each mcp session,
+// every session can load documents under different alias
+type Registry struct {
+	mu       sync.RWMutex
+	sessions map[string]*sessionDocuments
+}
+
+// sessionDocuments are documents that belong to one session
+type sessionDocuments struct {
+	active    string // alias of the last loaded document
+	documents map[string]*OpenAPI
+}
+
+// LoadedDocument describe a document that stored in Registry
+type LoadedDocument struct {
+	Alias  string `json:"alias" yaml:"alias"`
+	Active bool   `json:"active" yaml:"active"`
+	DocumentInfo
+}
+
+// NewRegistry create an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[string]*sessionDocuments),
+	}
+}
+
+// Store save document under sessionID and alias, it will also become the active document of that session
+func (r *Registry) Store(sessionID string, alias string, document *OpenAPI) {
+	if alias == "" {
+		alias = DefaultAlias
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		session = &sessionDocuments{
+			documents: make(map[string]*OpenAPI),
+		}
+		r.sessions[sessionID] = session
+	}
+
+	session.documents[alias] = document
+	session.active = alias
+}
+
+// Get return document by sessionID and alias, the active document will be return if alias is empty
+func (r *Registry) Get(sessionID string, alias string) (*OpenAPI, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok || len(session.documents) == 0 {
+		return nil, ErrDocumentNotLoaded
+	}
+
+	if alias == "" {
+		alias = session.active
+	}
+
+	document, ok := session.documents[alias]
+	if !ok {
+		return nil, fmt.Errorf("%w with alias %q", ErrDocumentNotLoaded, alias)
+	}
+
+	return document, nil
+}
+
+// List return all documents loaded by the session sorted by alias
+func (r *Registry) List(sessionID string) []LoadedDocument {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return []LoadedDocument{}
+	}
+
+	loadedDocuments := make([]LoadedDocument, 0, len(session.documents))
+
+	for alias, document := range session.documents {
+		loadedDocuments = append(loadedDocuments, LoadedDocument{
+			Alias:        alias,
+			Active:       alias == session.active,
+			DocumentInfo: document.Info(),
+		})
+	}
+
+	sort.Slice(loadedDocuments, func(i, j int) bool {
+		return loadedDocuments[i].Alias < loadedDocuments[j].Alias
+	})
+
+	return loadedDocuments
+}
+
+// RemoveSession delete all documents of the session, should be called when session closed
+func (r *Registry) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, sessionID)
+}
@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+func Test_Registry(t *testing.T) {
+	registry := NewRegistry()
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registry.Store(fmt.Sprintf("session-%d", i), "", &OpenAPI{})
+		}()
+	}
+	wg.Wait()
+
+	first := &OpenAPI{}
+	second := &OpenAPI{}
+	registry.Store("session-0", "users", first)
+	registry.Store("session-0", "orders", second)
+
+	if document, err := registry.Get("session-0", ""); err != nil || document != second {
+		t.Errorf("Last stored document should be active, got %v, error: %v", document, err)
+	}
+
+	if document, err := registry.Get("session-0", "users"); err != nil || document != first {
+		t.Errorf("Document should be found by alias, got %v, error: %v", document, err)
+	}
+
+	if _, err := registry.Get("session-1", "users"); !errors.Is(err, ErrDocumentNotLoaded) {
+		t.Errorf("Alias of other session should not be found, error: %v", err)
+	}
+
+	registry.RemoveSession("session-0")
+
+	if _, err := registry.Get("session-0", ""); !errors.Is(err, ErrDocumentNotLoaded) {
+		t.Errorf("Removed session should not have document, error: %v", err)
+	}
+}
+
+func Test_RegistryList(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 2.1.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: ok
+    post:
+      responses:
+        "201":
+          description: created
+`
+	document, err := newOpenAPI([]byte(spec), datamodel.NewDocumentConfiguration(), "users.yaml")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	registry := NewRegistry()
+	registry.Store("session", "users", document)
+	registry.Store("session", "another", document)
+
+	loadedDocuments := registry.List("session")
+
+	if len(loadedDocuments) != 2 {
+		t.Fatalf("Len of loadedDocuments should be %d, not %d", 2, len(loadedDocuments))
+	}
+
+	if loadedDocuments[0].Alias != "another" || !loadedDocuments[0].Active {
+		t.Errorf("First document should be active %q, not %+v", "another", loadedDocuments[0])
+	}
+
+	info := loadedDocuments[1].DocumentInfo
+	if info.Title != "Users" || info.Version != "2.1.0" || info.OperationCount != 2 || info.Source != "users.yaml" {
+		t.Errorf("Info is not match the document, got %+v", info)
+	}
+}
@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// SecurityScheme is a scheme defined in components.securitySchemes
+type SecurityScheme struct {
+	Name             string      `json:"name" yaml:"name"`                                             // key in components.securitySchemes
+	Type             string      `json:"type" yaml:"type"`                                             // apiKey, http, oauth2, openIdConnect or mutualTLS
+	Scheme           string      `json:"scheme,omitempty" yaml:"scheme,omitempty"`                     // bearer or basic when type is http
+	BearerFormat     string      `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`         // like JWT
+	In               string      `json:"in,omitempty" yaml:"in,omitempty"`                             // header, query or cookie when type is apiKey
+	ParameterName    string      `json:"parameterName,omitempty" yaml:"parameterName,omitempty"`       // header, query or cookie name when type is apiKey
+	Flows            []OAuthFlow `json:"flows,omitempty" yaml:"flows,omitempty"`                       // used when type is oauth2
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"` // used when type is openIdConnect
+	Description      string      `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// OAuth2 flow types
+const (
+	FlowClientCredentials = "clientCredentials"
+	FlowPassword          = "password"
+	FlowAuthorizationCode = "authorizationCode"
+	FlowImplicit          = "implicit"
+)
+
+// OAuthFlow is one flow of oauth2 scheme
+type OAuthFlow struct {
+	Type       string   `json:"type" yaml:"type"` // clientCredentials, password, authorizationCode or implicit
+	TokenURL   string   `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	RefreshURL string   `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+	Scopes     []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// Flow return oauth2 flow by type
+func (s SecurityScheme) Flow(flowType string) (OAuthFlow, bool) {
+	for _, flow := range s.Flows {
+		if flow.Type == flowType {
+			return flow, true
+		}
+	}
+	return OAuthFlow{}, false
+}
+
+// RequiredScheme is a scheme and its scopes required by operation
+type RequiredScheme struct {
+	Name   string   `json:"name" yaml:"name"`
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// SecurityRequirement is one way to satisfy security of operation, every scheme in it is required,
+// requirement without scheme means operation can be called anonymously
+type SecurityRequirement struct {
+	Schemes []RequiredScheme `json:"schemes" yaml:"schemes"`
+}
+
+// SecuritySchemes return every scheme in components.securitySchemes in document order
+func (o *OpenAPI) SecuritySchemes() []SecurityScheme {
+	components := o.docModelV3.Model.Components
+	if components == nil || components.SecuritySchemes == nil {
+		return []SecurityScheme{}
+	}
+
+	schemes := make([]SecurityScheme, 0, components.SecuritySchemes.Len())
+	for pair := components.SecuritySchemes.First(); pair != nil; pair = pair.Next() {
+		schemes = append(schemes, toSecurityScheme(pair.Key(), pair.Value()))
+	}
+
+	return schemes
+}
+
+// SecurityScheme return scheme by its name in components.securitySchemes
+func (o *OpenAPI) SecurityScheme(name string) (SecurityScheme, bool) {
+	components := o.docModelV3.Model.Components
+	if components == nil || components.SecuritySchemes == nil {
+		return SecurityScheme{}, false
+	}
+
+	scheme := components.SecuritySchemes.GetOrZero(name)
+	if scheme == nil {
+		return SecurityScheme{}, false
+	}
+
+	return toSecurityScheme(name, scheme), true
+}
+
+// OperationSecurity return alternative requirements of operation, any one of them is enough,
+// security of operation override security of document and empty result means no security is required
+func (o *OpenAPI) OperationSecurity(path string, method string) ([]SecurityRequirement, error) {
+	operation, err := o.GetOneAPIByPath(path, method)
+	if err != nil {
+		return nil, err
+	}
+
+	// nil means operation doesn't define security, empty slice means security is explicitly removed
+	security := operation.Security
+	if security == nil {
+		security = o.docModelV3.Model.Security
+	}
+
+	return toSecurityRequirements(security), nil
+}
+
+func toSecurityRequirements(security []*base.SecurityRequirement) []SecurityRequirement {
+	requirements := make([]SecurityRequirement, 0, len(security))
+
+	for _, requirement := range security {
+		converted := SecurityRequirement{
+			Schemes: []RequiredScheme{},
+		}
+
+		if requirement.Requirements != nil {
+			for pair := requirement.Requirements.First(); pair != nil; pair = pair.Next() {
+				converted.Schemes = append(converted.Schemes, RequiredScheme{
+					Name:   pair.Key(),
+					Scopes: pair.Value(),
+				})
+			}
+		}
+
+		requirements = append(requirements, converted)
+	}
+
+	return requirements
+}
+
+func toSecurityScheme(name string, scheme *v3high.SecurityScheme) SecurityScheme {
+	converted := SecurityScheme{
+		Name:             name,
+		Type:             scheme.Type,
+		Scheme:           scheme.Scheme,
+		BearerFormat:     scheme.BearerFormat,
+		In:               scheme.In,
+		ParameterName:    scheme.Name,
+		OpenIDConnectURL: scheme.OpenIdConnectUrl,
+		Description:      scheme.Description,
+	}
+
+	if scheme.Flows == nil {
+		return converted
+	}
+
+	flows := []struct {
+		flowType string
+		flow     *v3high.OAuthFlow
+	}{
+		{FlowClientCredentials, scheme.Flows.ClientCredentials},
+		{FlowPassword, scheme.Flows.Password},
+		{FlowAuthorizationCode, scheme.Flows.AuthorizationCode},
+		{FlowImplicit, scheme.Flows.Implicit},
+	}
+
+	for _, flow := range flows {
+		if flow.flow == nil {
+			continue
+		}
+
+		converted.Flows = append(converted.Flows, OAuthFlow{
+			Type:       flow.flowType,
+			TokenURL:   flow.flow.TokenUrl,
+			RefreshURL: flow.flow.RefreshUrl,
+			Scopes:     scopesOf(flow.flow),
+		})
+	}
+
+	return converted
+}
+
+func scopesOf(flow *v3high.OAuthFlow) []string {
+	if flow.Scopes == nil {
+		return nil
+	}
+
+	scopes := make([]string, 0, flow.Scopes.Len())
+	for pair := flow.Scopes.First(); pair != nil; pair = pair.Next() {
+		scopes = append(scopes, pair.Key())
+	}
+	return scopes
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Levels that server can be defined at, lower level override higher level
+const (
+	ServerLevelDocument  = "document"
+	ServerLevelPath      = "path"
+	ServerLevelOperation = "operation"
+)
+
+// Server is a server defined in document, url can contain variables like {region}
+type Server struct {
+	URL         string           `json:"url" yaml:"url"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Level       string           `json:"level" yaml:"level"` // document, path or operation
+	Variables   []ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// ServerVariable is a variable of server url
+type ServerVariable struct {
+	Name        string   `json:"name" yaml:"name"`
+	Default     string   `json:"default" yaml:"default"`
+	Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// Resolve replace variables of server url by values or their default,
+// value that is not in enum of variable will be rejected
+func (s Server) Resolve(values map[string]string) (string, error) {
+	serverURL := s.URL
+
+	for _, variable := range s.Variables {
+		value, ok := values[variable.Name]
+		if !ok || value == "" {
+			value = variable.Default
+		} else if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, value) {
+			return "", fmt.Errorf("Value %q of server variable %q should be one of %s", value, variable.Name, strings.Join(variable.Enum, ", "))
+		}
+
+		serverURL = strings.ReplaceAll(serverURL, "{"+variable.Name+"}", value)
+	}
+
+	return serverURL, nil
+}
+
+// DocumentServers return servers defined at root of document
+func (o *OpenAPI) DocumentServers() []Server {
+	return o.toServers(o.docModelV3.Model.Servers, ServerLevelDocument)
+}
+
+// PathServers return servers defined at path item and operation,
+// error will be return if path or method is not in document
+func (o *OpenAPI) PathServers(path string, method string) (pathServers []Server, operationServers []Server, err error) {
+	operation, err := o.GetOneAPIByPath(path, method)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pathItem := o.docModelV3.Model.Paths.PathItems.GetOrZero(path)
+
+	return o.toServers(pathItem.Servers, ServerLevelPath), o.toServers(operation.Servers, ServerLevelOperation), nil
+}
+
+// OperationServers return servers that apply to operation,
+// servers of operation override servers of path item and servers of path item override servers of document
+func (o *OpenAPI) OperationServers(path string, method string) ([]Server, error) {
+	pathServers, operationServers, err := o.PathServers(path, method)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(operationServers) > 0:
+		return operationServers, nil
+	case len(pathServers) > 0:
+		return pathServers, nil
+	}
+
+	return o.DocumentServers(), nil
+}
+
+// OperationServerURL return url of the first server that apply to operation with variables replaced,
+// empty string will be return if no server is defined
+func (o *OpenAPI) OperationServerURL(path string, method string, values map[string]string) (string, error) {
+	servers, err := o.OperationServers(path, method)
+	if err != nil || len(servers) == 0 {
+		return "", err
+	}
+
+	return servers[0].Resolve(values)
+}
+
+// DefaultServerURL return url of the first server in document with variables replaced by default,
+// empty string will be return if document has no server
+func (o *OpenAPI) DefaultServerURL() string {
+	servers := o.DocumentServers()
+	if len(servers) == 0 {
+		return ""
+	}
+
+	serverURL, _ := servers[0].Resolve(nil)
+	return serverURL
+}
+
+// toServers convert servers of model, relative url like /v1 will be resolved against url of document if it is read from url
+func (o *OpenAPI) toServers(servers []*v3high.Server, level string) []Server {
+	converted := make([]Server, 0, len(servers))
+
+	for _, server := range servers {
+		s := Server{
+			URL:         o.absoluteServerURL(server.URL),
+			Description: server.Description,
+			Level:       level,
+		}
+
+		if server.Variables != nil {
+			for pair := server.Variables.First(); pair != nil; pair = pair.Next() {
+				s.Variables = append(s.Variables, ServerVariable{
+					Name:        pair.Key(),
+					Default:     pair.Value().Default,
+					Enum:        pair.Value().Enum,
+					Description: pair.Value().Description,
+				})
+			}
+		}
+
+		converted = append(converted, s)
+	}
+
+	return converted
+}
+
+func (o *OpenAPI) absoluteServerURL(serverURL string) string {
+	if !IsRemotePath(o.source) || strings.Contains(serverURL, "://") {
+		return serverURL
+	}
+
+	sourceURL, err := url.Parse(o.source)
+	if err != nil {
+		return serverURL
+	}
+
+	// url.ResolveReference is not used because it will escape variables like {version}
+	if strings.HasPrefix(serverURL, "/") {
+		return sourceURL.Scheme + "://" + sourceURL.Host + serverURL
+	}
+
+	directory := sourceURL.Path[:strings.LastIndex(sourceURL.Path, "/")+1]
+	return sourceURL.Scheme + "://" + sourceURL.Host + directory + serverURL
+}
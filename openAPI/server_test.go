@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const serverSpec = `openapi: 3.0.3
+info:
+  title: Servers
+  version: 1.0.0
+servers:
+  - url: https://{region}.example.com/{version}
+    variables:
+      region:
+        default: eu
+        enum: [eu, us]
+      version:
+        default: v1
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: ok
+  /files:
+    servers:
+      - url: https://files.example.com
+    get:
+      responses:
+        "200":
+          description: ok
+    post:
+      servers:
+        - url: https://upload.example.com/v2
+      responses:
+        "201":
+          description: created
+`
+
+func Test_OperationServers(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "servers.yaml")
+	if err := os.WriteFile(specPath, []byte(serverSpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	testCases := []struct {
+		path     string
+		method   string
+		values   map[string]string
+		expected string
+	}{
+		{"/users", "get", nil, "https://eu.example.com/v1"},
+		{"/users", "get", map[string]string{"region": "us", "version": "v3"}, "https://us.example.com/v3"},
+		{"/files", "get", nil, "https://files.example.com"},
+		{"/files", "post", nil, "https://upload.example.com/v2"},
+	}
+
+	for _, testCase := range testCases {
+		serverURL, err := document.OperationServerURL(testCase.path, testCase.method, testCase.values)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+
+		if serverURL != testCase.expected {
+			t.Errorf("Server of %s %s should be %q, not %q", testCase.method, testCase.path, testCase.expected, serverURL)
+		}
+	}
+
+	if _, err := document.OperationServerURL("/users", "get", map[string]string{"region": "asia"}); err == nil {
+		t.Errorf("Value not in enum should be rejected")
+	}
+
+	// /v2 of operation server should also be stripped when matching request path
+	if matched, err := document.FindOperation("POST", "/v2/files"); err != nil || matched.Path != "/files" {
+		t.Errorf("Base path of operation server should be stripped, got %v %v", matched, err)
+	}
+}
+
+func Test_RelativeServerURL(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Relative
+  version: 1.0.0
+servers:
+  - url: /api/{version}
+    variables:
+      version:
+        default: v1
+paths: {}
+`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(spec))
+	}))
+	defer srv.Close()
+
+	document, err := ReadFromURL(srv.URL+"/docs/openapi.yaml", RemoteOption{})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if serverURL := document.DefaultServerURL(); serverURL != srv.URL+"/api/v1" {
+		t.Errorf("Relative server should be resolved against url of document, not %q", serverURL)
+	}
+}
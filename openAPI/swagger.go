@@ -0,0 +1,477 @@
+package openapi
+
+// This swagger.go convert Swagger 2.0 high level model into OpenAPI v3 high level model,
+// so every other function in this package only need to handle v3
+// more doc https://swagger.io/blog/api-strategy/difference-between-swagger-and-openapi/
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// convertedOpenAPIVersion is the version of document that converted from swagger 2.0
+const convertedOpenAPIVersion = "3.0.3"
+
+const (
+	mimeJSON           = "application/json"
+	mimeFormURLEncoded = "application/x-www-form-urlencoded"
+	mimeMultipartForm  = "multipart/form-data"
+)
+
+// convertSwaggerToV3 convert swagger 2.0 document into OpenAPI v3 document
+func convertSwaggerToV3(swagger *v2high.Swagger) *v3high.Document {
+	document := &v3high.Document{
+		Version:      convertedOpenAPIVersion,
+		Info:         swagger.Info,
+		Servers:      convertSwaggerServers(swagger),
+		Security:     swagger.Security,
+		Tags:         swagger.Tags,
+		ExternalDocs: swagger.ExternalDocs,
+		Components:   convertSwaggerComponents(swagger),
+		Paths: &v3high.Paths{
+			PathItems: orderedmap.New[string, *v3high.PathItem](),
+		},
+	}
+
+	if swagger.Paths == nil || swagger.Paths.PathItems == nil {
+		return document
+	}
+
+	for pathPairs := swagger.Paths.PathItems.First(); pathPairs != nil; pathPairs = pathPairs.Next() {
+		document.Paths.PathItems.Set(pathPairs.Key(), convertSwaggerPathItem(swagger, pathPairs.Value()))
+	}
+
+	return document
+}
+
+// convertSwaggerServers build servers from schemes, host and basePath
+func convertSwaggerServers(swagger *v2high.Swagger) []*v3high.Server {
+	basePath := swagger.BasePath
+	if basePath == "/" {
+		basePath = ""
+	}
+
+	// Without host, url is relative to where the document is served
+	if swagger.Host == "" {
+		if basePath == "" {
+			return nil
+		}
+		return []*v3high.Server{{URL: basePath}}
+	}
+
+	schemes := swagger.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	servers := make([]*v3high.Server, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, &v3high.Server{
+			URL: fmt.Sprintf("%s://%s%s", scheme, swagger.Host, basePath),
+		})
+	}
+
+	return servers
+}
+
+// convertSwaggerComponents move definitions, parameters, responses and securityDefinitions into components
+func convertSwaggerComponents(swagger *v2high.Swagger) *v3high.Components {
+	components := &v3high.Components{
+		Schemas:         orderedmap.New[string, *base.SchemaProxy](),
+		Parameters:      orderedmap.New[string, *v3high.Parameter](),
+		Responses:       orderedmap.New[string, *v3high.Response](),
+		SecuritySchemes: orderedmap.New[string, *v3high.SecurityScheme](),
+	}
+
+	if swagger.Definitions != nil && swagger.Definitions.Definitions != nil {
+		for pair := swagger.Definitions.Definitions.First(); pair != nil; pair = pair.Next() {
+			components.Schemas.Set(pair.Key(), pair.Value())
+		}
+	}
+
+	if swagger.Parameters != nil && swagger.Parameters.Definitions != nil {
+		for pair := swagger.Parameters.Definitions.First(); pair != nil; pair = pair.Next() {
+			// body and formData parameter become requestBody in v3
+			if parameter := convertSwaggerParameter(pair.Value()); parameter != nil {
+				components.Parameters.Set(pair.Key(), parameter)
+			}
+		}
+	}
+
+	if swagger.Responses != nil && swagger.Responses.Definitions != nil {
+		for pair := swagger.Responses.Definitions.First(); pair != nil; pair = pair.Next() {
+			components.Responses.Set(pair.Key(), convertSwaggerResponse(pair.Value(), swagger.Produces))
+		}
+	}
+
+	if swagger.SecurityDefinitions != nil && swagger.SecurityDefinitions.Definitions != nil {
+		for pair := swagger.SecurityDefinitions.Definitions.First(); pair != nil; pair = pair.Next() {
+			components.SecuritySchemes.Set(pair.Key(), convertSwaggerSecurityScheme(pair.Value()))
+		}
+	}
+
+	return components
+}
+
+// convertSwaggerPathItem convert every operation in path item,
+// parameters of path item will be merged into each operation
+func convertSwaggerPathItem(swagger *v2high.Swagger, pathItem *v2high.PathItem) *v3high.PathItem {
+	convert := func(operation *v2high.Operation) *v3high.Operation {
+		if operation == nil {
+			return nil
+		}
+		return convertSwaggerOperation(swagger, pathItem.Parameters, operation)
+	}
+
+	return &v3high.PathItem{
+		Get:     convert(pathItem.Get),
+		Put:     convert(pathItem.Put),
+		Post:    convert(pathItem.Post),
+		Delete:  convert(pathItem.Delete),
+		Options: convert(pathItem.Options),
+		Head:    convert(pathItem.Head),
+		Patch:   convert(pathItem.Patch),
+	}
+}
+
+// convertSwaggerOperation convert operation, body and formData parameters will become requestBody
+func convertSwaggerOperation(swagger *v2high.Swagger, pathParameters []*v2high.Parameter, operation *v2high.Operation) *v3high.Operation {
+	consumes := firstNonEmpty(operation.Consumes, swagger.Consumes, []string{mimeJSON})
+	produces := firstNonEmpty(operation.Produces, swagger.Produces, []string{mimeJSON})
+
+	var parameters []*v3high.Parameter
+	var bodyParameter *v2high.Parameter
+	var formParameters []*v2high.Parameter
+
+	for _, parameter := range mergeSwaggerParameters(pathParameters, operation.Parameters) {
+		switch parameter.In {
+		case "body":
+			bodyParameter = parameter
+		case "formData":
+			formParameters = append(formParameters, parameter)
+		default:
+			parameters = append(parameters, convertSwaggerParameter(parameter))
+		}
+	}
+
+	v3Operation := &v3high.Operation{
+		Tags:         operation.Tags,
+		Summary:      operation.Summary,
+		Description:  operation.Description,
+		ExternalDocs: operation.ExternalDocs,
+		OperationId:  operation.OperationId,
+		Parameters:   parameters,
+		Security:     operation.Security,
+		Responses: &v3high.Responses{
+			Codes: orderedmap.New[string, *v3high.Response](),
+		},
+	}
+
+	if operation.Deprecated {
+		deprecated := true
+		v3Operation.Deprecated = &deprecated
+	}
+
+	switch {
+	case bodyParameter != nil:
+		v3Operation.RequestBody = convertSwaggerBodyParameter(bodyParameter, consumes)
+	case len(formParameters) > 0:
+		v3Operation.RequestBody = convertSwaggerFormParameters(formParameters, consumes)
+	}
+
+	if operation.Responses != nil {
+		if operation.Responses.Codes != nil {
+			for pair := operation.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+				v3Operation.Responses.Codes.Set(pair.Key(), convertSwaggerResponse(pair.Value(), produces))
+			}
+		}
+
+		if operation.Responses.Default != nil {
+			v3Operation.Responses.Default = convertSwaggerResponse(operation.Responses.Default, produces)
+		}
+	}
+
+	return v3Operation
+}
+
+// mergeSwaggerParameters let operation parameters override path item parameters with same name and location
+func mergeSwaggerParameters(pathParameters []*v2high.Parameter, operationParameters []*v2high.Parameter) []*v2high.Parameter {
+	merged := make([]*v2high.Parameter, 0, len(pathParameters)+len(operationParameters))
+	overridden := make(map[string]bool, len(operationParameters))
+
+	for _, parameter := range operationParameters {
+		overridden[parameter.In+":"+parameter.Name] = true
+	}
+
+	for _, parameter := range pathParameters {
+		if !overridden[parameter.In+":"+parameter.Name] {
+			merged = append(merged, parameter)
+		}
+	}
+
+	return append(merged, operationParameters...)
+}
+
+// convertSwaggerParameter convert query, header and path parameter, body and formData will return nil
+func convertSwaggerParameter(parameter *v2high.Parameter) *v3high.Parameter {
+	if parameter.In == "body" || parameter.In == "formData" {
+		return nil
+	}
+
+	v3Parameter := &v3high.Parameter{
+		Name:        parameter.Name,
+		In:          parameter.In,
+		Description: parameter.Description,
+		Required:    parameter.Required,
+		Schema:      base.CreateSchemaProxy(swaggerParameterSchema(parameter)),
+	}
+
+	if parameter.AllowEmptyValue != nil {
+		v3Parameter.AllowEmptyValue = *parameter.AllowEmptyValue
+	}
+
+	// csv is the default of swagger 2.0 and the same as form without explode in v3
+	if parameter.Type == "array" {
+		explode := parameter.CollectionFormat == "multi"
+		v3Parameter.Explode = &explode
+
+		switch parameter.CollectionFormat {
+		case "ssv":
+			v3Parameter.Style = "spaceDelimited"
+		case "pipes":
+			v3Parameter.Style = "pipeDelimited"
+		}
+	}
+
+	return v3Parameter
+}
+
+// convertSwaggerBodyParameter convert body parameter into requestBody with every consumes media type
+func convertSwaggerBodyParameter(parameter *v2high.Parameter, consumes []string) *v3high.RequestBody {
+	content := orderedmap.New[string, *v3high.MediaType]()
+	for _, mediaType := range consumes {
+		content.Set(mediaType, &v3high.MediaType{Schema: parameter.Schema})
+	}
+
+	return &v3high.RequestBody{
+		Description: parameter.Description,
+		Required:    parameter.Required,
+		Content:     content,
+	}
+}
+
+// convertSwaggerFormParameters combine all formData parameters into one object schema
+func convertSwaggerFormParameters(parameters []*v2high.Parameter, consumes []string) *v3high.RequestBody {
+	schema := &base.Schema{
+		Type:       []string{"object"},
+		Properties: orderedmap.New[string, *base.SchemaProxy](),
+	}
+
+	mediaType := mimeFormURLEncoded
+	anyRequired := false
+
+	for _, parameter := range parameters {
+		schema.Properties.Set(parameter.Name, base.CreateSchemaProxy(swaggerParameterSchema(parameter)))
+
+		if parameter.Required != nil && *parameter.Required {
+			schema.Required = append(schema.Required, parameter.Name)
+			anyRequired = true
+		}
+
+		if parameter.Type == "file" {
+			mediaType = mimeMultipartForm
+		}
+	}
+
+	for _, consume := range consumes {
+		if consume == mimeMultipartForm {
+			mediaType = mimeMultipartForm
+		}
+	}
+
+	content := orderedmap.New[string, *v3high.MediaType]()
+	content.Set(mediaType, &v3high.MediaType{Schema: base.CreateSchemaProxy(schema)})
+
+	return &v3high.RequestBody{
+		Required: &anyRequired,
+		Content:  content,
+	}
+}
+
+// convertSwaggerResponse put response schema and examples under every produces media type
+func convertSwaggerResponse(response *v2high.Response, produces []string) *v3high.Response {
+	v3Response := &v3high.Response{
+		Description: response.Description,
+	}
+
+	if response.Headers != nil {
+		v3Response.Headers = orderedmap.New[string, *v3high.Header]()
+
+		for pair := response.Headers.First(); pair != nil; pair = pair.Next() {
+			header := pair.Value()
+			v3Response.Headers.Set(pair.Key(), &v3high.Header{
+				Description: header.Description,
+				Schema: base.CreateSchemaProxy(&base.Schema{
+					Type:   []string{header.Type},
+					Format: header.Format,
+				}),
+			})
+		}
+	}
+
+	if response.Schema == nil {
+		return v3Response
+	}
+
+	v3Response.Content = orderedmap.New[string, *v3high.MediaType]()
+
+	for _, mediaType := range produces {
+		v3MediaType := &v3high.MediaType{Schema: response.Schema}
+
+		if response.Examples != nil && response.Examples.Values != nil {
+			v3MediaType.Example = response.Examples.Values.GetOrZero(mediaType)
+		}
+
+		v3Response.Content.Set(mediaType, v3MediaType)
+	}
+
+	return v3Response
+}
+
+// convertSwaggerSecurityScheme convert basic into http scheme and oauth2 flow into flows
+func convertSwaggerSecurityScheme(scheme *v2high.SecurityScheme) *v3high.SecurityScheme {
+	v3Scheme := &v3high.SecurityScheme{
+		Type:        scheme.Type,
+		Description: scheme.Description,
+		Name:        scheme.Name,
+		In:          scheme.In,
+	}
+
+	switch scheme.Type {
+	case "basic":
+		v3Scheme.Type = "http"
+		v3Scheme.Scheme = "basic"
+	case "oauth2":
+		flow := &v3high.OAuthFlow{
+			AuthorizationUrl: scheme.AuthorizationUrl,
+			TokenUrl:         scheme.TokenUrl,
+			Scopes:           orderedmap.New[string, string](),
+		}
+
+		if scheme.Scopes != nil && scheme.Scopes.Values != nil {
+			flow.Scopes = scheme.Scopes.Values
+		}
+
+		v3Scheme.Flows = &v3high.OAuthFlows{}
+
+		switch scheme.Flow {
+		case "implicit":
+			v3Scheme.Flows.Implicit = flow
+		case "password":
+			v3Scheme.Flows.Password = flow
+		case "application":
+			v3Scheme.Flows.ClientCredentials = flow
+		case "accessCode":
+			v3Scheme.Flows.AuthorizationCode = flow
+		}
+	}
+
+	return v3Scheme
+}
+
+// swaggerParameterSchema build schema from type, format and validation keywords of non body parameter
+func swaggerParameterSchema(parameter *v2high.Parameter) *base.Schema {
+	schema := &base.Schema{
+		Type:        []string{swaggerType(parameter.Type)},
+		Format:      parameter.Format,
+		Description: parameter.Description,
+		Default:     parameter.Default,
+		Enum:        parameter.Enum,
+		Pattern:     parameter.Pattern,
+		UniqueItems: parameter.UniqueItems,
+		MaxLength:   intToInt64(parameter.MaxLength),
+		MinLength:   intToInt64(parameter.MinLength),
+		MaxItems:    intToInt64(parameter.MaxItems),
+		MinItems:    intToInt64(parameter.MinItems),
+		Maximum:     intToFloat64(parameter.Maximum),
+		Minimum:     intToFloat64(parameter.Minimum),
+		MultipleOf:  intToFloat64(parameter.MultipleOf),
+	}
+
+	if parameter.Type == "file" {
+		schema.Format = "binary"
+	}
+
+	if parameter.ExclusiveMaximum != nil {
+		schema.ExclusiveMaximum = &base.DynamicValue[bool, float64]{A: *parameter.ExclusiveMaximum}
+	}
+
+	if parameter.ExclusiveMinimum != nil {
+		schema.ExclusiveMinimum = &base.DynamicValue[bool, float64]{A: *parameter.ExclusiveMinimum}
+	}
+
+	if parameter.Items != nil {
+		schema.Items = &base.DynamicValue[*base.SchemaProxy, bool]{
+			A: base.CreateSchemaProxy(swaggerItemsSchema(parameter.Items)),
+		}
+	}
+
+	return schema
+}
+
+// swaggerItemsSchema build schema of array items
+func swaggerItemsSchema(items *v2high.Items) *base.Schema {
+	schema := &base.Schema{
+		Type:    []string{swaggerType(items.Type)},
+		Format:  items.Format,
+		Default: items.Default,
+		Enum:    items.Enum,
+		Pattern: items.Pattern,
+	}
+
+	if items.Items != nil {
+		schema.Items = &base.DynamicValue[*base.SchemaProxy, bool]{
+			A: base.CreateSchemaProxy(swaggerItemsSchema(items.Items)),
+		}
+	}
+
+	return schema
+}
+
+// swaggerType convert "file" into "string", other types are the same in v3
+func swaggerType(swaggerType string) string {
+	if swaggerType == "file" {
+		return "string"
+	}
+	return swaggerType
+}
+
+// firstNonEmpty return the first list that has value
+func firstNonEmpty(lists ...[]string) []string {
+	for _, list := range lists {
+		if len(list) > 0 {
+			return list
+		}
+	}
+	return nil
+}
+
+func intToInt64(value *int) *int64 {
+	if value == nil {
+		return nil
+	}
+	converted := int64(*value)
+	return &converted
+}
+
+func intToFloat64(value *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	converted := float64(*value)
+	return &converted
+}
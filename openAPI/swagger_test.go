@@ -0,0 +1,138 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+const swaggerSpec = `swagger: "2.0"
+info:
+  title: Pet Store
+  version: 1.0.0
+host: petstore.example.com
+basePath: /v1
+schemes:
+  - https
+securityDefinitions:
+  api_key:
+    type: apiKey
+    name: X-API-Key
+    in: header
+  oauth:
+    type: oauth2
+    flow: application
+    tokenUrl: https://petstore.example.com/token
+    scopes:
+      read: read pets
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        type: integer
+    get:
+      operationId: getPet
+      parameters:
+        - name: fields
+          in: query
+          type: array
+          items:
+            type: string
+      responses:
+        "200":
+          description: ok
+          schema:
+            $ref: "#/definitions/Pet"
+    put:
+      parameters:
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: "#/definitions/Pet"
+      responses:
+        "204":
+          description: updated
+  /pets/{petId}/photo:
+    post:
+      consumes:
+        - multipart/form-data
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          type: integer
+        - name: file
+          in: formData
+          required: true
+          type: file
+      responses:
+        "201":
+          description: uploaded
+definitions:
+  Pet:
+    type: object
+    required:
+      - name
+    properties:
+      name:
+        type: string
+`
+
+func Test_ReadSwagger(t *testing.T) {
+	openAPI, err := newOpenAPI([]byte(swaggerSpec), datamodel.NewDocumentConfiguration(), "swagger.yaml")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	info := openAPI.Info()
+	if info.SpecVersion != "2.0" || info.OperationCount != 3 {
+		t.Errorf("Info should keep swagger version and count operations, got %+v", info)
+	}
+
+	servers := openAPI.docModelV3.Model.Servers
+	if len(servers) != 1 || servers[0].URL != "https://petstore.example.com/v1" {
+		t.Errorf("Servers should be built from schemes, host and basePath, got %v", servers)
+	}
+
+	getPet, err := openAPI.GetOneAPIByPath("/pets/{petId}", "get")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(getPet.Parameters) != 2 || getPet.Parameters[0].Name != "petId" {
+		t.Errorf("Path item parameters should be merged into operation, got %d parameters", len(getPet.Parameters))
+	}
+
+	petSchema := getPet.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema()
+	if petSchema == nil || len(petSchema.Required) != 1 || petSchema.Required[0] != "name" {
+		t.Errorf("Response schema should be moved into content, got %v", petSchema)
+	}
+
+	putPet, err := openAPI.GetOneAPIByPath("/pets/{petId}", "put")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if putPet.RequestBody == nil || putPet.RequestBody.Content.GetOrZero("application/json") == nil {
+		t.Errorf("Body parameter should become requestBody")
+	}
+
+	uploadPhoto, err := openAPI.GetOneAPIByPath("/pets/{petId}/photo", "post")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	formMediaType := uploadPhoto.RequestBody.Content.GetOrZero("multipart/form-data")
+	if formMediaType == nil || formMediaType.Schema.Schema().Properties.GetOrZero("file") == nil {
+		t.Errorf("FormData parameters should become multipart requestBody")
+	}
+
+	oauth := openAPI.docModelV3.Model.Components.SecuritySchemes.GetOrZero("oauth")
+	if oauth == nil || oauth.Flows == nil || oauth.Flows.ClientCredentials == nil ||
+		oauth.Flows.ClientCredentials.TokenUrl != "https://petstore.example.com/token" {
+		t.Errorf("Application flow should become clientCredentials flow, got %+v", oauth)
+	}
+}
@@ -0,0 +1,259 @@
+// Package postman will import Postman v2.1 collection and environment,
+// every request is converted into scenario step so it can be run by the same runner as scenarios
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Collection is Postman collection v2.1, only fields that can be converted are decoded
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Variable []Variable `json:"variable,omitempty"`
+	Auth     *Auth      `json:"auth,omitempty"`
+	Event    []Event    `json:"event,omitempty"`
+}
+
+// Info describe collection
+type Info struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// Item is a request or a folder of items
+type Item struct {
+	Name    string   `json:"name"`
+	Item    []Item   `json:"item,omitempty"` // folder if not nil
+	Request *Request `json:"request,omitempty"`
+	Auth    *Auth    `json:"auth,omitempty"` // auth of folder
+	Event   []Event  `json:"event,omitempty"`
+}
+
+// IsFolder tell whether item contain other items instead of request
+func (i Item) IsFolder() bool {
+	return i.Request == nil && i.Item != nil
+}
+
+// Request is request of item, it can be a plain url string in collection
+type Request struct {
+	Method string   `json:"method"`
+	Header []KeyVal `json:"header,omitempty"`
+	URL    URL      `json:"url"`
+	Body   *Body    `json:"body,omitempty"`
+	Auth   *Auth    `json:"auth,omitempty"`
+}
+
+// UnmarshalJSON accept request written as url string
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		*r = Request{Method: "GET", URL: URL{Raw: rawURL}}
+		return nil
+	}
+
+	type request Request
+	var decoded request
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*r = Request(decoded)
+
+	if r.Method == "" {
+		r.Method = "GET"
+	}
+	return nil
+}
+
+// URL is url of request, it can be a plain string in collection
+type URL struct {
+	Raw      string   `json:"raw"`
+	Host     []string `json:"host,omitempty"`
+	Path     []string `json:"path,omitempty"`
+	Query    []KeyVal `json:"query,omitempty"`
+	Variable []KeyVal `json:"variable,omitempty"` // value of path variable like :id
+}
+
+// UnmarshalJSON accept url written as string
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		*u = URL{Raw: rawURL}
+		return nil
+	}
+
+	type postmanURL URL
+	var decoded postmanURL
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*u = URL(decoded)
+	return nil
+}
+
+// KeyVal is header, query param, form field or auth attribute
+type KeyVal struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// String return value as string, auth attributes can be number or boolean
+func (k KeyVal) String() string {
+	switch v := k.Value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(k.Value)
+}
+
+// Body is request body, mode decide which field is used
+type Body struct {
+	Mode       string   `json:"mode"`
+	Raw        string   `json:"raw,omitempty"`
+	URLEncoded []KeyVal `json:"urlencoded,omitempty"`
+	FormData   []KeyVal `json:"formdata,omitempty"`
+	GraphQL    *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables,omitempty"`
+	} `json:"graphql,omitempty"`
+	Options *struct {
+		Raw *struct {
+			Language string `json:"language"`
+		} `json:"raw,omitempty"`
+	} `json:"options,omitempty"`
+}
+
+// Auth is auth of collection, folder or request, attributes are under field named by type
+type Auth struct {
+	Type   string   `json:"type"`
+	Bearer []KeyVal `json:"bearer,omitempty"`
+	Basic  []KeyVal `json:"basic,omitempty"`
+	APIKey []KeyVal `json:"apikey,omitempty"`
+}
+
+// attribute return value of auth attribute like token of bearer
+func attribute(attributes []KeyVal, key string) string {
+	for _, attr := range attributes {
+		if attr.Key == key {
+			return attr.String()
+		}
+	}
+	return ""
+}
+
+// Event is prerequest or test script
+type Event struct {
+	Listen string `json:"listen"`
+	Script Script `json:"script"`
+}
+
+// Script is lines of javascript, exec can be a string or list of lines
+type Script struct {
+	Exec []string `json:"exec"`
+}
+
+// UnmarshalJSON accept exec written as string
+func (s *Script) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Exec json.RawMessage `json:"exec"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if len(decoded.Exec) == 0 {
+		return nil
+	}
+
+	var exec string
+	if err := json.Unmarshal(decoded.Exec, &exec); err == nil {
+		s.Exec = strings.Split(exec, "\n")
+		return nil
+	}
+
+	return json.Unmarshal(decoded.Exec, &s.Exec)
+}
+
+// Variable is collection variable
+type Variable struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// Environment is Postman environment, only enabled values are kept
+type Environment struct {
+	Name   string            `json:"name"`
+	Values map[string]string `json:"values"`
+}
+
+// ParseCollection decode collection v2.1, v2.0 is also accepted since they have the same shape
+func ParseCollection(content []byte) (*Collection, error) {
+	var collection Collection
+	if err := json.Unmarshal(content, &collection); err != nil {
+		return nil, fmt.Errorf("Error happened when parse Postman collection, error: %w", err)
+	}
+
+	if collection.Info.Schema != "" && !strings.Contains(collection.Info.Schema, "v2.") {
+		return nil, fmt.Errorf("Postman collection schema %q is not supported, please export it as v2.1", collection.Info.Schema)
+	}
+
+	if collection.Item == nil {
+		return nil, fmt.Errorf("Postman collection %q has no item", collection.Info.Name)
+	}
+
+	return &collection, nil
+}
+
+// LoadCollection read collection from file
+func LoadCollection(path string) (*Collection, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when read Postman collection %q, error: %w", path, err)
+	}
+	return ParseCollection(content)
+}
+
+// ParseEnvironment decode environment exported by Postman
+func ParseEnvironment(content []byte) (*Environment, error) {
+	var decoded struct {
+		Name   string `json:"name"`
+		Values []struct {
+			Key     string `json:"key"`
+			Value   any    `json:"value"`
+			Enabled *bool  `json:"enabled"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		return nil, fmt.Errorf("Error happened when parse Postman environment, error: %w", err)
+	}
+
+	environment := &Environment{
+		Name:   decoded.Name,
+		Values: make(map[string]string, len(decoded.Values)),
+	}
+	for _, value := range decoded.Values {
+		if value.Enabled != nil && !*value.Enabled {
+			continue
+		}
+		environment.Values[value.Key] = KeyVal{Value: value.Value}.String()
+	}
+
+	return environment, nil
+}
+
+// LoadEnvironment read environment from file
+func LoadEnvironment(path string) (*Environment, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when read Postman environment %q, error: %w", path, err)
+	}
+	return ParseEnvironment(content)
+}
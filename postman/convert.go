@@ -0,0 +1,375 @@
+package postman
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mcp-api-tester/scenario"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Imported is collection converted into scenario steps
+type Imported struct {
+	Name        string            `json:"name"`
+	Environment string            `json:"environment,omitempty"`
+	Variables   map[string]any    `json:"variables"` // collection variables overridden by environment values
+	Requests    []ImportedRequest `json:"requests"`
+	Warnings    []string          `json:"warnings,omitempty"` // things in collection that can't be converted
+}
+
+// ImportedRequest is one request of collection
+type ImportedRequest struct {
+	ID       string        `json:"id"` // step id like get_user, unique in collection
+	Name     string        `json:"name"`
+	Folder   string        `json:"folder,omitempty"` // folders joined by / like Users/Admin
+	Step     scenario.Step `json:"step"`
+	Warnings []string      `json:"warnings,omitempty"`
+}
+
+// Convert flatten folders in order and convert every request, auth and test script into scenario step,
+// auth and test scripts of collection and folders are inherited by requests under them
+func Convert(collection *Collection, environment *Environment) *Imported {
+	imported := &Imported{
+		Name:      collection.Info.Name,
+		Variables: map[string]any{},
+	}
+
+	for _, variable := range collection.Variable {
+		if !variable.Disabled {
+			imported.Variables[variable.Key] = KeyVal{Value: variable.Value}.String()
+		}
+	}
+
+	if environment != nil {
+		imported.Environment = environment.Name
+		for key, value := range environment.Values {
+			imported.Variables[key] = value
+		}
+	}
+
+	c := &converter{imported: imported, ids: map[string]bool{}}
+	c.walk(collection.Item, nil, collection.Auth, collection.Event)
+
+	return imported
+}
+
+type converter struct {
+	imported *Imported
+	ids      map[string]bool
+}
+
+// walk convert items under folders, auth and events are what items inherit from parents
+func (c *converter) walk(items []Item, folders []string, auth *Auth, events []Event) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil && item.Auth.Type != "inherit" {
+			itemAuth = item.Auth
+		}
+		itemEvents := append(slices.Clone(events), item.Event...)
+
+		if item.IsFolder() {
+			c.walk(item.Item, append(slices.Clone(folders), item.Name), itemAuth, itemEvents)
+			continue
+		}
+
+		if item.Request == nil {
+			c.imported.Warnings = append(c.imported.Warnings, fmt.Sprintf("Item %q has no request", item.Name))
+			continue
+		}
+
+		c.imported.Requests = append(c.imported.Requests, c.convertItem(item, strings.Join(folders, "/"), itemAuth, itemEvents))
+	}
+}
+
+func (c *converter) convertItem(item Item, folder string, auth *Auth, events []Event) ImportedRequest {
+	request := item.Request
+
+	imported := ImportedRequest{
+		ID:     c.uniqueID(item.Name),
+		Name:   item.Name,
+		Folder: folder,
+	}
+
+	step := scenario.Step{
+		ID: imported.ID,
+		Request: scenario.Request{
+			Method:  strings.ToUpper(request.Method),
+			URL:     convertURL(request.URL),
+			Headers: map[string]string{},
+		},
+	}
+
+	for _, header := range request.Header {
+		if !header.Disabled && header.Key != "" {
+			step.Headers[header.Key] = header.String()
+		}
+	}
+
+	if request.Body != nil {
+		imported.Warnings = append(imported.Warnings, convertBody(request.Body, &step.Request)...)
+	}
+
+	if request.Auth != nil && request.Auth.Type != "inherit" {
+		auth = request.Auth
+	}
+	imported.Warnings = append(imported.Warnings, applyAuth(auth, &step.Request)...)
+
+	for _, event := range events {
+		switch event.Listen {
+		case "test":
+			assertions, statuses, extract, warnings := convertTests(event.Script.Exec)
+			step.Assert = append(step.Assert, assertions...)
+			step.ExpectStatus = append(step.ExpectStatus, statuses...)
+			for name, source := range extract {
+				if step.Extract == nil {
+					step.Extract = map[string]string{}
+				}
+				step.Extract[name] = source
+			}
+			imported.Warnings = append(imported.Warnings, warnings...)
+		case "prerequest":
+			if hasCode(event.Script.Exec) {
+				imported.Warnings = append(imported.Warnings, "Pre-request script is not converted")
+			}
+		}
+	}
+
+	if strings.Contains(step.URL, "{{$") {
+		imported.Warnings = append(imported.Warnings, "Dynamic variables like {{$guid}} are not supported, please set them by variables")
+	}
+
+	imported.Step = step
+	return imported
+}
+
+// uniqueID make step id from name, ids can be used in template like {{steps.get_user.body.id}}
+func (c *converter) uniqueID(name string) string {
+	id := strings.Trim(nonWordPattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if id == "" {
+		id = "request"
+	}
+
+	candidate := id
+	for i := 2; c.ids[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", id, i)
+	}
+	c.ids[candidate] = true
+
+	return candidate
+}
+
+var (
+	nonWordPattern     = regexp.MustCompile(`[^a-z0-9]+`)
+	pathVariablePatten = regexp.MustCompile(`/:([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// convertURL use raw url and replace path variables like :id by their values or {{id}}
+func convertURL(u URL) string {
+	raw := u.Raw
+	if raw == "" {
+		raw = strings.Join(u.Host, ".")
+		if len(u.Path) > 0 {
+			raw += "/" + strings.Join(u.Path, "/")
+		}
+		query := make([]string, 0, len(u.Query))
+		for _, q := range u.Query {
+			if !q.Disabled {
+				query = append(query, q.Key+"="+q.String())
+			}
+		}
+		if len(query) > 0 {
+			raw += "?" + strings.Join(query, "&")
+		}
+	}
+
+	raw = pathVariablePatten.ReplaceAllStringFunc(raw, func(match string) string {
+		name := match[2:]
+		for _, variable := range u.Variable {
+			if variable.Key == name && variable.String() != "" {
+				return "/" + variable.String()
+			}
+		}
+		return "/{{" + name + "}}"
+	})
+
+	// postman send url without scheme by http
+	if !strings.Contains(raw, "://") && !strings.HasPrefix(raw, "{{") && !strings.HasPrefix(raw, "/") {
+		raw = "http://" + raw
+	}
+
+	return raw
+}
+
+// convertBody keep raw body as string so templates in it still work
+func convertBody(body *Body, request *scenario.Request) []string {
+	switch body.Mode {
+	case "raw":
+		request.Body = body.Raw
+		if body.Options != nil && body.Options.Raw != nil && body.Options.Raw.Language == "json" {
+			request.ContentType = "application/json"
+		}
+	case "urlencoded":
+		fields := make([]string, 0, len(body.URLEncoded))
+		for _, field := range body.URLEncoded {
+			if !field.Disabled {
+				fields = append(fields, formEscape(field.Key)+"="+formEscape(field.String()))
+			}
+		}
+		request.Body = strings.Join(fields, "&")
+		request.ContentType = "application/x-www-form-urlencoded"
+	case "graphql":
+		if body.GraphQL == nil {
+			return nil
+		}
+		graphQL := map[string]any{"query": body.GraphQL.Query}
+		if body.GraphQL.Variables != "" {
+			var variables any
+			if err := json.Unmarshal([]byte(body.GraphQL.Variables), &variables); err == nil {
+				graphQL["variables"] = variables
+			}
+		}
+		request.Body = graphQL
+	case "", "none":
+	default:
+		return []string{fmt.Sprintf("Body of mode %q is not converted", body.Mode)}
+	}
+	return nil
+}
+
+// formEscape escape form value but keep template like {{name}} so it can be rendered later
+func formEscape(value string) string {
+	if strings.Contains(value, "{{") {
+		return value
+	}
+	return url.QueryEscape(value)
+}
+
+// applyAuth put credential of auth into header or query that request hasn't set
+func applyAuth(auth *Auth, request *scenario.Request) []string {
+	if auth == nil {
+		return nil
+	}
+
+	switch auth.Type {
+	case "noauth", "":
+	case "bearer":
+		setHeaderIfAbsent(request.Headers, "Authorization", "Bearer "+attribute(auth.Bearer, "token"))
+	case "basic":
+		username, password := attribute(auth.Basic, "username"), attribute(auth.Basic, "password")
+		if strings.Contains(username+password, "{{") {
+			return []string{"Basic auth with variables is not converted, please set Authorization header or credential instead"}
+		}
+		setHeaderIfAbsent(request.Headers, "Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	case "apikey":
+		key, value := attribute(auth.APIKey, "key"), attribute(auth.APIKey, "value")
+		if attribute(auth.APIKey, "in") == "query" {
+			if request.QueryParams == nil {
+				request.QueryParams = map[string]string{}
+			}
+			if _, ok := request.QueryParams[key]; !ok {
+				request.QueryParams[key] = value
+			}
+		} else {
+			setHeaderIfAbsent(request.Headers, key, value)
+		}
+	default:
+		return []string{fmt.Sprintf("Auth of type %q is not converted", auth.Type)}
+	}
+
+	return nil
+}
+
+func setHeaderIfAbsent(headers map[string]string, key string, value string) {
+	for name := range headers {
+		if http.CanonicalHeaderKey(name) == http.CanonicalHeaderKey(key) {
+			return
+		}
+	}
+	headers[key] = value
+}
+
+// hasCode tell whether script has any line that is not empty or comment
+func hasCode(lines []string) bool {
+	return slices.ContainsFunc(lines, func(line string) bool {
+		line = strings.TrimSpace(line)
+		return line != "" && !strings.HasPrefix(line, "//")
+	})
+}
+
+// Select return requests under folder like Users or Users/Admin, or requests of ids, every request if both are empty
+func (i *Imported) Select(folder string, ids []string) ([]ImportedRequest, error) {
+	folder = strings.Trim(folder, "/")
+
+	var selected []ImportedRequest
+	for _, request := range i.Requests {
+		if folder != "" && request.Folder != folder && !strings.HasPrefix(request.Folder, folder+"/") {
+			continue
+		}
+		if len(ids) > 0 && !slices.Contains(ids, request.ID) {
+			continue
+		}
+		selected = append(selected, request)
+	}
+
+	for _, id := range ids {
+		if !slices.ContainsFunc(selected, func(request ImportedRequest) bool { return request.ID == id }) {
+			return nil, fmt.Errorf("Request %q is not found in collection %q", id, i.Name)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("No request is found in folder %q of collection %q", folder, i.Name)
+	}
+
+	return selected, nil
+}
+
+// Scenario run requests in order and keep going after failure like postman runner
+func (i *Imported) Scenario(requests []ImportedRequest) scenario.Scenario {
+	s := scenario.Scenario{
+		Name:              i.Name,
+		Variables:         make(map[string]any, len(i.Variables)),
+		Steps:             make([]scenario.Step, 0, len(requests)),
+		ContinueOnFailure: true,
+	}
+
+	for key, value := range i.Variables {
+		s.Variables[key] = value
+	}
+
+	for _, request := range requests {
+		s.Steps = append(s.Steps, request.Step)
+	}
+
+	return s
+}
+
+// RequestSummary describe imported request without its step
+type RequestSummary struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Folder   string   `json:"folder,omitempty"`
+	Method   string   `json:"method"`
+	URL      string   `json:"url"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Summaries describe requests in order
+func Summaries(requests []ImportedRequest) []RequestSummary {
+	summaries := make([]RequestSummary, 0, len(requests))
+	for _, request := range requests {
+		summaries = append(summaries, RequestSummary{
+			ID:       request.ID,
+			Name:     request.Name,
+			Folder:   request.Folder,
+			Method:   request.Step.Method,
+			URL:      request.Step.URL,
+			Warnings: request.Warnings,
+		})
+	}
+	return summaries
+}
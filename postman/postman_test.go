@@ -0,0 +1,214 @@
+package postman
+
+import (
+	"encoding/json"
+	"mcp-api-tester/assertion"
+	"mcp-api-tester/scenario"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const collectionJSON = `{
+  "info": {"name": "Users API", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+  "variable": [{"key": "baseUrl", "value": "http://localhost"}],
+  "item": [
+    {
+      "name": "Users",
+      "item": [
+        {
+          "name": "Create user",
+          "request": {
+            "method": "POST",
+            "url": "{{baseUrl}}/users",
+            "body": {"mode": "raw", "raw": "{\"name\": \"{{name}}\"}", "options": {"raw": {"language": "json"}}}
+          },
+          "event": [{"listen": "test", "script": {"exec": [
+            "pm.test(\"created\", function () {",
+            "    pm.response.to.have.status(201);",
+            "});",
+            "var jsonData = pm.response.json();",
+            "pm.expect(jsonData.name).to.eql(pm.environment.get(\"name\"));",
+            "pm.expect(pm.response.responseTime).to.be.below(5000);",
+            "pm.collectionVariables.set(\"userId\", jsonData.id);",
+            "pm.sendRequest(\"https://example.com\");"
+          ]}}]
+        },
+        {
+          "name": "Get user",
+          "request": {
+            "method": "GET",
+            "url": {"raw": "{{baseUrl}}/users/:id", "variable": [{"key": "id", "value": "{{userId}}"}]},
+            "auth": {"type": "noauth"}
+          },
+          "event": [{"listen": "test", "script": {"exec": "pm.test(\"ok\", () => { pm.response.to.be.ok; pm.expect(pm.response.json()).to.have.property(\"name\"); });\npm.response.to.have.header(\"Content-Type\");"}}]
+        }
+      ]
+    },
+    {"name": "Health", "request": "{{baseUrl}}/health"}
+  ]
+}`
+
+const environmentJSON = `{
+  "name": "Local",
+  "values": [
+    {"key": "name", "value": "Alice", "enabled": true},
+    {"key": "token", "value": "secret", "enabled": true},
+    {"key": "unused", "value": "x", "enabled": false}
+  ]
+}`
+
+func importTestCollection(t *testing.T) *Imported {
+	t.Helper()
+
+	collection, err := ParseCollection([]byte(collectionJSON))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	environment, err := ParseEnvironment([]byte(environmentJSON))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return Convert(collection, environment)
+}
+
+func Test_Convert(t *testing.T) {
+	imported := importTestCollection(t)
+
+	ids := []string{}
+	for _, request := range imported.Requests {
+		ids = append(ids, request.Folder+":"+request.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"Users:create_user", "Users:get_user", ":health"}) {
+		t.Fatalf("Requests should be flatten in order, got %v", ids)
+	}
+
+	if _, ok := imported.Variables["unused"]; ok || imported.Variables["baseUrl"] != "http://localhost" || imported.Variables["token"] != "secret" {
+		t.Errorf("Enabled environment values should be merged with collection variables, got %v", imported.Variables)
+	}
+
+	create := imported.Requests[0]
+	if create.Step.Headers["Authorization"] != "Bearer {{token}}" || create.Step.ContentType != "application/json" {
+		t.Errorf("Collection auth and raw json body should be converted, got %+v", create.Step.Request)
+	}
+	if !reflect.DeepEqual(create.Step.ExpectStatus, []int{201}) || create.Step.Extract["userId"] != "$.id" || len(create.Step.Assert) != 2 {
+		t.Errorf("Test script should be converted, got %+v", create.Step)
+	}
+	if len(create.Warnings) != 1 || !strings.Contains(create.Warnings[0], "pm.sendRequest") {
+		t.Errorf("Unsupported script line should be warned, got %v", create.Warnings)
+	}
+
+	get := imported.Requests[1]
+	if _, ok := get.Step.Headers["Authorization"]; ok || get.Step.URL != "{{baseUrl}}/users/{{userId}}" {
+		t.Errorf("noauth and path variable should be converted, got %+v", get.Step.Request)
+	}
+	if !reflect.DeepEqual(get.Step.ExpectStatus, []int{200}) || len(get.Step.Assert) != 2 || get.Step.Assert[0].Path != `$["name"]` {
+		t.Errorf("Single line test script should be converted, got %+v", get.Step)
+	}
+
+	if selected, err := imported.Select("Users", nil); err != nil || len(selected) != 2 {
+		t.Errorf("Folder should select its requests, got %v %v", selected, err)
+	}
+	if _, err := imported.Select("", []string{"missing"}); err == nil {
+		t.Errorf("Unknown request id should be error")
+	}
+}
+
+func Test_ConvertTests(t *testing.T) {
+	cases := []struct {
+		line     string
+		expected assertion.Assertion
+	}{
+		{`pm.expect(pm.response.code).to.be.oneOf([200, 201]);`, assertion.Assertion{}},
+		{`pm.response.to.be.success;`, assertion.Assertion{Source: assertion.SourceStatus, Op: assertion.OpRange, Value: "2xx"}},
+		{`pm.response.to.have.header("X-Id", "1");`, assertion.Assertion{Source: assertion.SourceHeader, Path: "X-Id", Op: assertion.OpEquals, Value: "1"}},
+		{`pm.expect(pm.response.text()).to.include("ok");`, assertion.Assertion{Source: assertion.SourceBody, Op: assertion.OpContains, Value: "ok"}},
+		{`pm.expect(pm.response.headers.get("Content-Type")).to.match(/json/);`, assertion.Assertion{Source: assertion.SourceHeader, Path: "Content-Type", Op: assertion.OpMatches, Value: "json"}},
+		{`pm.expect(pm.response.json().items).to.have.lengthOf(2);`, assertion.Assertion{Source: assertion.SourceJSONPath, Path: "$.items", Op: assertion.OpLength, Value: 2.0}},
+		{`pm.expect(pm.response.json().items[0]["first-name"]).to.be.a('string');`, assertion.Assertion{Source: assertion.SourceJSONPath, Path: `$.items[0]["first-name"]`, Op: assertion.OpType, Value: "string"}},
+		{`pm.expect(pm.response.json().active).to.be.true;`, assertion.Assertion{Source: assertion.SourceJSONPath, Path: "$.active", Op: assertion.OpEquals, Value: true}},
+		{`pm.expect(pm.response.json().id).to.not.eql(0);`, assertion.Assertion{Source: assertion.SourceJSONPath, Path: "$.id", Op: assertion.OpNotEquals, Value: 0.0}},
+	}
+
+	for _, c := range cases {
+		assertions, statuses, _, warnings := convertTests([]string{c.line})
+		if len(warnings) > 0 {
+			t.Errorf("%s should be converted, got warnings %v", c.line, warnings)
+			continue
+		}
+
+		if c.expected.Source == "" {
+			if !reflect.DeepEqual(statuses, []int{200, 201}) {
+				t.Errorf("%s should be expected statuses, got %v", c.line, statuses)
+			}
+			continue
+		}
+
+		if len(assertions) != 1 || !reflect.DeepEqual(assertions[0], c.expected) {
+			t.Errorf("%s expected %+v, got %+v", c.line, c.expected, assertions)
+		}
+	}
+
+	_, _, extract, _ := convertTests([]string{`pm.environment.set("location", pm.response.headers.get("Location"));`})
+	if extract["location"] != "headers.Location" {
+		t.Errorf("Header should be extracted, got %v", extract)
+	}
+
+	_, _, _, warnings := convertTests([]string{`pm.expect(Date.now()).to.be.above(0);`})
+	if len(warnings) != 1 {
+		t.Errorf("Unsupported expectation should be warned, got %v", warnings)
+	}
+}
+
+func Test_RunImported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/users":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var user map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			user["id"] = 7
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(user)
+		case r.URL.Path == "/users/7" && r.Header.Get("Authorization") == "":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 7, "name": "Alice"})
+		case r.URL.Path == "/health":
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	imported := importTestCollection(t)
+	imported.Variables["baseUrl"] = server.URL
+
+	s := imported.Scenario(imported.Requests)
+	if err := s.Validate(); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	result := scenario.Run(t.Context(), s)
+	if !result.Passed {
+		resultBytes, _ := json.MarshalIndent(result, "", "  ")
+		t.Fatalf("Collection should pass, got %s", resultBytes)
+	}
+
+	if !slices.ContainsFunc(result.Steps[1].Checks, func(check assertion.Result) bool { return strings.Contains(check.Name, "name") }) {
+		t.Errorf("Converted assertion should be checked, got %+v", result.Steps[1].Checks)
+	}
+}
@@ -0,0 +1,115 @@
+package postman
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrCollectionNotImported will be return when session hasn't imported any collection
+var ErrCollectionNotImported = errors.New("Postman collection hasn't been imported")
+
+// Collections is the registry that tools share
+var Collections = NewRegistry()
+
+// Registry store imported collections for each mcp session by collection name
+type Registry struct {
+	mu       sync.RWMutex
+	sessions map[string]*sessionCollections
+}
+
+// sessionCollections are collections that belong to one session
+type sessionCollections struct {
+	active      string // name of the last imported collection
+	collections map[string]*Imported
+}
+
+// ImportedCollection describe a collection that stored in Registry
+type ImportedCollection struct {
+	Name        string `json:"name"`
+	Environment string `json:"environment,omitempty"`
+	Requests    int    `json:"requests"`
+	Active      bool   `json:"active"`
+}
+
+// NewRegistry create an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		sessions: make(map[string]*sessionCollections),
+	}
+}
+
+// Store save collection under its name, it will also become the active collection of that session
+func (r *Registry) Store(sessionID string, imported *Imported) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		session = &sessionCollections{
+			collections: make(map[string]*Imported),
+		}
+		r.sessions[sessionID] = session
+	}
+
+	session.collections[imported.Name] = imported
+	session.active = imported.Name
+}
+
+// Get return collection by name, the active collection will be return if name is empty
+func (r *Registry) Get(sessionID string, name string) (*Imported, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok || len(session.collections) == 0 {
+		return nil, ErrCollectionNotImported
+	}
+
+	if name == "" {
+		name = session.active
+	}
+
+	imported, ok := session.collections[name]
+	if !ok {
+		return nil, fmt.Errorf("%w with name %q", ErrCollectionNotImported, name)
+	}
+
+	return imported, nil
+}
+
+// List return all collections imported by the session sorted by name
+func (r *Registry) List(sessionID string) []ImportedCollection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return []ImportedCollection{}
+	}
+
+	collections := make([]ImportedCollection, 0, len(session.collections))
+	for name, imported := range session.collections {
+		collections = append(collections, ImportedCollection{
+			Name:        name,
+			Environment: imported.Environment,
+			Requests:    len(imported.Requests),
+			Active:      name == session.active,
+		})
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+
+	return collections
+}
+
+// RemoveSession delete all collections of the session, should be called when session closed
+func (r *Registry) RemoveSession(sessionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, sessionID)
+}
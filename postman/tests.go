@@ -0,0 +1,428 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"mcp-api-tester/assertion"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// jsonAliasPattern match var jsonData = pm.response.json()
+	jsonAliasPattern = regexp.MustCompile(`(?:var|let|const)\s+(\w+)\s*=\s*pm\.response\.json\(\)`)
+
+	statusPattern = regexp.MustCompile(`pm\.response\.to\.have\.status\(\s*(\d{3})\s*\)`)
+	okPattern     = regexp.MustCompile(`pm\.response\.to\.be\.(ok|success)\b`)
+	headerPattern = regexp.MustCompile(`pm\.response\.to\.have\.header\(\s*["']([^"']+)["']\s*(?:,\s*["']([^"']*)["']\s*)?\)`)
+
+	// testPattern match pm.test("name", function () {
+	testPattern = regexp.MustCompile(`pm\.test\(\s*(?:"[^"]*"|'[^']*'|` + "`[^`]*`" + `)\s*,\s*(?:function\s*\(\s*\)|\(\s*\)\s*=>)\s*\{`)
+
+	// chainPattern match chain after pm.expect(...) like .to.be.below or .to.not.have.property
+	chainPattern = regexp.MustCompile(`^\.to((?:\.\w+)+)`)
+
+	setPattern = regexp.MustCompile(`pm\.(?:environment|collectionVariables|globals|variables)\.set\(`)
+	getPattern = regexp.MustCompile(`^pm\.(?:environment|collectionVariables|globals|variables)\.get\(\s*["'](\w+)["']\s*\)$`)
+
+	headerGetPattern = regexp.MustCompile(`^pm\.response\.headers\.get\(\s*["']([^"']+)["']\s*\)$`)
+)
+
+// tests is what converted from one test script
+type tests struct {
+	aliases    []string // variables that hold pm.response.json()
+	assertions []assertion.Assertion
+	statuses   []int
+	extract    map[string]string
+	warnings   []string
+}
+
+// convertTests convert common pm.test checks into assertions and expected statuses,
+// and pm.environment.set of response value into extract, line that can't be converted is reported as warning
+func convertTests(lines []string) ([]assertion.Assertion, []int, map[string]string, []string) {
+	t := &tests{extract: map[string]string{}}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		for _, match := range jsonAliasPattern.FindAllStringSubmatch(line, -1) {
+			t.aliases = append(t.aliases, match[1])
+		}
+
+		if !t.convertLine(line) && strings.Contains(line, "pm.") && !isStructure(line) {
+			t.warnings = append(t.warnings, fmt.Sprintf("Test script line is not converted: %s", line))
+		}
+	}
+
+	return t.assertions, t.statuses, t.extract, t.warnings
+}
+
+// isStructure tell whether line only declare test or alias
+func isStructure(line string) bool {
+	rest := jsonAliasPattern.ReplaceAllString(line, "")
+	rest = testPattern.ReplaceAllString(rest, "")
+	return !strings.Contains(rest, "pm.")
+}
+
+// convertLine return true if anything in line is converted
+func (t *tests) convertLine(line string) bool {
+	converted := false
+
+	for _, match := range statusPattern.FindAllStringSubmatch(line, -1) {
+		status, _ := strconv.Atoi(match[1])
+		t.statuses = append(t.statuses, status)
+		converted = true
+	}
+
+	for _, match := range okPattern.FindAllStringSubmatch(line, -1) {
+		if match[1] == "ok" {
+			t.statuses = append(t.statuses, 200)
+		} else {
+			t.assertions = append(t.assertions, assertion.Assertion{Source: assertion.SourceStatus, Op: assertion.OpRange, Value: "2xx"})
+		}
+		converted = true
+	}
+
+	for _, match := range headerPattern.FindAllStringSubmatch(line, -1) {
+		a := assertion.Assertion{Source: assertion.SourceHeader, Path: match[1], Op: assertion.OpExists}
+		if strings.Contains(match[0], ",") {
+			a.Op, a.Value = assertion.OpEquals, match[2]
+		}
+		t.assertions = append(t.assertions, a)
+		converted = true
+	}
+
+	for start := strings.Index(line, "pm.expect("); start >= 0; {
+		open := start + len("pm.expect")
+		end := closingParen(line, open)
+		if end < 0 {
+			break
+		}
+
+		if t.convertExpect(line[open+1:end], line[end+1:]) {
+			converted = true
+		} else {
+			t.warnings = append(t.warnings, fmt.Sprintf("Expectation is not converted: %s", line))
+			converted = true // warned already
+		}
+
+		next := strings.Index(line[end:], "pm.expect(")
+		if next < 0 {
+			break
+		}
+		start = end + next
+	}
+
+	for _, index := range setPattern.FindAllStringIndex(line, -1) {
+		open := index[1] - 1
+		end := closingParen(line, open)
+		if end < 0 {
+			continue
+		}
+
+		name, expression, ok := splitArguments(line[open+1 : end])
+		name, isString := stringLiteral(name)
+		source, isSource := t.source(expression)
+		if !ok || !isString || !isSource {
+			t.warnings = append(t.warnings, fmt.Sprintf("Variable set from script is not converted: %s", line[index[0]:end+1]))
+			converted = true
+			continue
+		}
+
+		t.extract[name] = source
+		converted = true
+	}
+
+	return converted
+}
+
+// convertExpect convert pm.expect(subject).to.<chain>(argument)
+func (t *tests) convertExpect(subject string, rest string) bool {
+	match := chainPattern.FindStringSubmatch(rest)
+	if match == nil {
+		return false
+	}
+
+	// words like be and have don't change meaning, the last word is the method
+	words := strings.Split(strings.TrimPrefix(match[1], "."), ".")
+	negated := slices.Contains(words, "not")
+	method := words[len(words)-1]
+
+	var argument any
+	hasArgument := false
+	if after := rest[len(match[0]):]; strings.HasPrefix(after, "(") {
+		end := closingParen(after, 0)
+		if end < 0 {
+			return false
+		}
+		if text := strings.TrimSpace(after[1:end]); text != "" {
+			var ok bool
+			if argument, ok = t.literal(text); !ok {
+				return false
+			}
+			hasArgument = true
+		}
+	}
+
+	subject = strings.TrimSpace(subject)
+
+	switch {
+	case subject == "pm.response.code":
+		return t.expectStatus(method, negated, argument)
+	case subject == "pm.response.responseTime":
+		if (method == "below" || method == "lessThan") && !negated && hasArgument {
+			t.assertions = append(t.assertions, assertion.Assertion{Source: assertion.SourceResponseTime, Op: assertion.OpBelow, Value: argument})
+			return true
+		}
+		return false
+	case subject == "pm.response.text()":
+		return t.expect(assertion.Assertion{Source: assertion.SourceBody}, method, negated, argument)
+	}
+
+	if match := headerGetPattern.FindStringSubmatch(subject); match != nil {
+		return t.expect(assertion.Assertion{Source: assertion.SourceHeader, Path: match[1]}, method, negated, argument)
+	}
+
+	if path, ok := t.jsonPath(subject); ok {
+		return t.expect(assertion.Assertion{Source: assertion.SourceJSONPath, Path: path}, method, negated, argument)
+	}
+
+	return false
+}
+
+// expectStatus put exact status into expected statuses so default status check doesn't conflict
+func (t *tests) expectStatus(method string, negated bool, argument any) bool {
+	if negated {
+		return false
+	}
+
+	switch method {
+	case "eql", "equal", "equals":
+		if status, ok := argument.(float64); ok {
+			t.statuses = append(t.statuses, int(status))
+			return true
+		}
+	case "oneOf":
+		values, ok := argument.([]any)
+		if !ok {
+			return false
+		}
+		for _, value := range values {
+			status, ok := value.(float64)
+			if !ok {
+				return false
+			}
+			t.statuses = append(t.statuses, int(status))
+		}
+		return true
+	}
+
+	return false
+}
+
+// expect fill op and value of assertion by chai method
+func (t *tests) expect(a assertion.Assertion, method string, negated bool, argument any) bool {
+	switch method {
+	case "eql", "equal", "equals":
+		a.Op, a.Value = assertion.OpEquals, argument
+		if negated {
+			a.Op = assertion.OpNotEquals
+		}
+	case "include", "includes", "contain", "contains", "string":
+		a.Op, a.Value = assertion.OpContains, argument
+	case "match":
+		a.Op, a.Value = assertion.OpMatches, argument
+	case "a", "an":
+		a.Op, a.Value = assertion.OpType, argument
+	case "lengthOf", "length":
+		a.Op, a.Value = assertion.OpLength, argument
+	case "property":
+		name, ok := argument.(string)
+		if !ok || a.Source != assertion.SourceJSONPath {
+			return false
+		}
+		a.Path += fmt.Sprintf("[%q]", name)
+		a.Op = assertion.OpExists
+		if negated {
+			a.Op = assertion.OpNotExists
+		}
+	case "exist":
+		a.Op = assertion.OpExists
+		if negated {
+			a.Op = assertion.OpNotExists
+		}
+	case "true", "false":
+		a.Op, a.Value = assertion.OpEquals, method == "true"
+		if negated {
+			a.Op = assertion.OpNotEquals
+		}
+	default:
+		return false
+	}
+
+	if negated && a.Op != assertion.OpNotEquals && a.Op != assertion.OpNotExists {
+		return false
+	}
+
+	if err := a.Validate(); err != nil {
+		return false
+	}
+
+	t.assertions = append(t.assertions, a)
+	return true
+}
+
+// jsonPath convert jsonData.items[0].id or pm.response.json().id into $.items[0].id
+func (t *tests) jsonPath(subject string) (string, bool) {
+	rest, ok := strings.CutPrefix(subject, "pm.response.json()")
+	if !ok {
+		for _, alias := range t.aliases {
+			if after, found := strings.CutPrefix(subject, alias); found && (after == "" || after[0] == '.' || after[0] == '[') {
+				rest, ok = after, true
+				break
+			}
+		}
+	}
+
+	if !ok || !jsAccessPattern.MatchString(rest) {
+		return "", false
+	}
+
+	return "$" + rest, true
+}
+
+// jsAccessPattern match property access like .items[0]["first-name"]
+var jsAccessPattern = regexp.MustCompile(`^(?:\.[A-Za-z_$][\w$]*|\[\d+\]|\[\s*"[^"]*"\s*\]|\[\s*'[^']*'\s*\])*$`)
+
+// source convert expression that set variable into extract source like $.token, headers.Location or status
+func (t *tests) source(expression string) (string, bool) {
+	expression = strings.TrimSpace(expression)
+
+	if expression == "pm.response.code" {
+		return "status", true
+	}
+	if match := headerGetPattern.FindStringSubmatch(expression); match != nil {
+		return "headers." + match[1], true
+	}
+	return t.jsonPath(expression)
+}
+
+// literal convert javascript literal into json value, variables like pm.environment.get("id") become {{id}}
+func (t *tests) literal(text string) (any, bool) {
+	if value, ok := stringLiteral(text); ok {
+		return value, true
+	}
+
+	if match := getPattern.FindStringSubmatch(text); match != nil {
+		return "{{" + match[1] + "}}", true
+	}
+
+	// regex literal like /^abc$/i, flags are dropped
+	if strings.HasPrefix(text, "/") {
+		if end := strings.LastIndex(text, "/"); end > 0 {
+			return text[1:end], true
+		}
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(strings.ReplaceAll(text, "'", `"`)), &value); err == nil {
+		return value, true
+	}
+
+	return nil, false
+}
+
+// stringLiteral unquote "text" or 'text'
+func stringLiteral(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if len(text) < 2 {
+		return "", false
+	}
+
+	switch text[0] {
+	case '"':
+		value, err := strconv.Unquote(text)
+		return value, err == nil
+	case '\'', '`':
+		if text[len(text)-1] == text[0] {
+			return text[1 : len(text)-1], true
+		}
+	}
+
+	return "", false
+}
+
+// closingParen return index of ) that close ( at open, quotes are skipped
+func closingParen(text string, open int) int {
+	depth := 0
+	var quote byte
+
+	for i := open; i < len(text); i++ {
+		c := text[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				if c != ')' {
+					return -1
+				}
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitArguments split two arguments at the first comma outside quotes and brackets
+func splitArguments(text string) (string, string, bool) {
+	depth := 0
+	var quote byte
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), true
+			}
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,98 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_JSON(t *testing.T) {
+	redactor := New(DefaultConfig())
+
+	input := `{
+		"request": {
+			"url": "https://example.com/users?api_key=abc123&page=2",
+			"headers": {"Authorization": "Bearer abc.def", "Content-Type": "application/json", "X-Api-Key": "k1"},
+			"cookies": {"session": "s1"},
+			"body": {"name": "tom", "password": "p@ss", "tokenUrl": "https://auth/token", "count": 3}
+		},
+		"response": {"body": "{\"accessToken\":\"t1\",\"id\":1}"}
+	}`
+
+	output := redactor.JSON([]byte(input))
+
+	var decoded map[string]any
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("output is not json: %v, %s", err, output)
+	}
+
+	request := decoded["request"].(map[string]any)
+	headers := request["headers"].(map[string]any)
+	if headers["Authorization"] != Mask || headers["X-Api-Key"] != Mask {
+		t.Errorf("sensitive headers should be masked, got %v", headers)
+	}
+	if headers["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type should be kept, got %v", headers["Content-Type"])
+	}
+
+	if request["cookies"].(map[string]any)["session"] != Mask {
+		t.Errorf("cookie should be masked, got %v", request["cookies"])
+	}
+
+	body := request["body"].(map[string]any)
+	if body["password"] != Mask || body["name"] != "tom" || body["tokenUrl"] != "https://auth/token" {
+		t.Errorf("unexpected body %v", body)
+	}
+	if body["count"] != float64(3) {
+		t.Errorf("number should be kept, got %v", body["count"])
+	}
+
+	url := request["url"].(string)
+	if strings.Contains(url, "abc123") || !strings.Contains(url, "page=2") {
+		t.Errorf("api_key in url should be masked, got %q", url)
+	}
+
+	responseBody := decoded["response"].(map[string]any)["body"].(string)
+	if strings.Contains(responseBody, "t1") || !strings.Contains(responseBody, `"id":1`) {
+		t.Errorf("token in raw body should be masked, got %q", responseBody)
+	}
+}
+
+func Test_String(t *testing.T) {
+	redactor := New(Config{Fields: []string{"*pin"}})
+
+	tests := []struct {
+		input    string
+		contains string
+		leaked   string
+	}{
+		{"call failed with Authorization: Bearer abc.def-123", "Bearer " + Mask, "abc.def-123"},
+		{"GET /login?user=tom&pin=1234", "pin=" + Mask, "1234"},
+		{`body {"userPin": "9876"}`, `"userPin": "` + Mask + `"`, "9876"},
+	}
+
+	for _, test := range tests {
+		output := redactor.String(test.input)
+		if !strings.Contains(output, test.contains) || strings.Contains(output, test.leaked) {
+			t.Errorf("String(%q) = %q", test.input, output)
+		}
+	}
+}
+
+func Test_Disabled(t *testing.T) {
+	redactor := Disabled()
+
+	input := `{"headers":{"Authorization":"Bearer abc"}}`
+	if output := string(redactor.JSON([]byte(input))); output != input {
+		t.Errorf("disabled redactor should keep input, got %s", output)
+	}
+}
+
+func Test_Writer(t *testing.T) {
+	var buffer bytes.Buffer
+	New(DefaultConfig()).Writer(&buffer).Write([]byte("token=abc\n"))
+	if buffer.String() != "token="+Mask+"\n" {
+		t.Errorf("writer should redact log line, got %q", buffer.String())
+	}
+}
@@ -0,0 +1,156 @@
+package report
+
+import (
+	"mcp-api-tester/scenario"
+	"mcp-api-tester/storage"
+	testsuite "mcp-api-tester/testSuite"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// FromSuite convert result of test suite, cases are grouped by the first tag of operation or its path
+func FromSuite(result *testsuite.Result, timestamp time.Time) *Report {
+	cases := make([]Case, 0, len(result.Tests))
+
+	for _, test := range result.Tests {
+		group := operationPath(test.Operation)
+		if len(test.Tags) > 0 {
+			group = test.Tags[0]
+		}
+
+		cases = append(cases, fromStep(test.Name, group, test.Operation, test.StepResult))
+	}
+
+	return New(result.Name, timestamp, result.ElapsedMs, cases)
+}
+
+// FromScenario convert result of scenario, every step is a case in the group named by scenario
+func FromScenario(result *scenario.Result, timestamp time.Time) *Report {
+	group := result.Name
+	if group == "" {
+		group = "scenario"
+	}
+
+	cases := make([]Case, 0, len(result.Steps))
+
+	for _, step := range result.Steps {
+		operation := ""
+		if step.Request != nil {
+			operation = strings.ToUpper(step.Request.Method) + " " + urlPath(step.Request.URL)
+		}
+
+		cases = append(cases, fromStep(step.ID, group, operation, step))
+	}
+
+	return New(result.Name, timestamp, result.ElapsedMs, cases)
+}
+
+// FromRecords convert stored history into report in the order they were created,
+// tagsOf can return tags of operation like GET /users/{id} to group cases, path is used if it is nil or return nothing
+func FromRecords(name string, records []storage.Record, tagsOf func(operation string) []string) *Report {
+	cases := make([]Case, 0, len(records))
+
+	var first, last time.Time
+	var elapsedMs int64
+
+	// records are queried newest first
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+
+		if first.IsZero() || record.CreatedAt.Before(first) {
+			first = record.CreatedAt
+		}
+		if record.CreatedAt.After(last) {
+			last = record.CreatedAt
+		}
+
+		group := operationPath(record.Operation)
+		if tagsOf != nil {
+			if tags := tagsOf(record.Operation); len(tags) > 0 {
+				group = tags[0]
+			}
+		}
+
+		c := Case{
+			Name:      record.Operation,
+			Group:     group,
+			Operation: record.Operation,
+			Passed:    record.Passed,
+		}
+
+		if record.Response != nil {
+			c.StatusCode = record.Response.StatusCode
+			c.ElapsedMs = record.Response.ElapsedMs
+			elapsedMs += record.Response.ElapsedMs
+		}
+
+		for _, assertion := range record.Assertions {
+			if !assertion.Passed {
+				c.Failures = append(c.Failures, Failure{
+					Name:     assertion.Name,
+					Message:  assertion.Message,
+					Expected: assertion.Expected,
+					Actual:   assertion.Actual,
+				})
+			}
+		}
+
+		if record.ErrorMessage != "" {
+			if record.Response == nil {
+				c.Error = record.ErrorMessage
+			} else if !record.Passed {
+				c.Failures = append(c.Failures, Failure{Name: "error", Message: record.ErrorMessage})
+			}
+		}
+
+		cases = append(cases, c)
+	}
+
+	return New(name, first, elapsedMs, cases)
+}
+
+func fromStep(name string, group string, operation string, step scenario.StepResult) Case {
+	c := Case{
+		Name:      name,
+		Group:     group,
+		Operation: operation,
+		Passed:    step.Passed,
+		Skipped:   step.Skipped,
+		Error:     step.Error,
+	}
+
+	if step.Response != nil {
+		c.StatusCode = step.Response.StatusCode
+		c.ElapsedMs = step.Response.ElapsedMs
+	}
+
+	for _, check := range step.Checks {
+		if !check.Passed {
+			c.Failures = append(c.Failures, Failure{
+				Name:     check.Name,
+				Message:  check.Message,
+				Expected: check.Expected,
+				Actual:   check.Actual,
+			})
+		}
+	}
+
+	return c
+}
+
+// operationPath return path of operation like /users/{id} from GET /users/{id}
+func operationPath(operation string) string {
+	if _, path, ok := strings.Cut(operation, " "); ok {
+		return path
+	}
+	return operation
+}
+
+func urlPath(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Path == "" {
+		return rawURL
+	}
+	return parsedURL.Path
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// maxDiffLines cap lines of each side, so huge body doesn't make a huge lcs table
+const maxDiffLines = 500
+
+// Diff return line diff between pretty json of expected and actual,
+// removed lines start with "- ", added lines with "+ " and same lines with "  "
+func Diff(expected any, actual any) string {
+	expectedLines := diffLines(expected)
+	actualLines := diffLines(actual)
+
+	// lcs[i][j] is length of longest common lines of expectedLines[i:] and actualLines[j:]
+	lcs := make([][]int, len(expectedLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(actualLines)+1)
+	}
+
+	for i := len(expectedLines) - 1; i >= 0; i-- {
+		for j := len(actualLines) - 1; j >= 0; j-- {
+			if expectedLines[i] == actualLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var builder strings.Builder
+	i, j := 0, 0
+
+	for i < len(expectedLines) || j < len(actualLines) {
+		switch {
+		case i < len(expectedLines) && j < len(actualLines) && expectedLines[i] == actualLines[j]:
+			builder.WriteString("  " + expectedLines[i] + "\n")
+			i++
+			j++
+		case i < len(expectedLines) && (j == len(actualLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			builder.WriteString("- " + expectedLines[i] + "\n")
+			i++
+		default:
+			builder.WriteString("+ " + actualLines[j] + "\n")
+			j++
+		}
+	}
+
+	return builder.String()
+}
+
+// diffLines split pretty json into lines, lines after maxDiffLines are replaced by a marker
+func diffLines(value any) []string {
+	lines := strings.Split(pretty(value), "\n")
+	if len(lines) <= maxDiffLines {
+		return lines
+	}
+
+	return append(lines[:maxDiffLines], fmt.Sprintf("… %d lines truncated", len(lines)-maxDiffLines))
+}
+
+func pretty(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
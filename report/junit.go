@@ -0,0 +1,137 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// renderJUnit put every group into one testsuite and every case into testcase
+func renderJUnit(report *Report) ([]byte, error) {
+	suites := junitTestSuites{
+		Name:     report.Name,
+		Tests:    report.Summary.Total,
+		Failures: report.Summary.Failed,
+		Errors:   report.Summary.Errored,
+		Skipped:  report.Summary.Skipped,
+		Time:     seconds(report.ElapsedMs),
+	}
+
+	names, groups := report.Groups()
+
+	for _, name := range names {
+		suite := junitTestSuite{Name: name}
+		if !report.Timestamp.IsZero() {
+			suite.Timestamp = report.Timestamp.UTC().Format("2006-01-02T15:04:05")
+		}
+
+		var elapsedMs int64
+		for _, c := range groups[name] {
+			elapsedMs += c.ElapsedMs
+			suite.Tests++
+
+			testCase := junitTestCase{
+				Name:      c.Name,
+				ClassName: c.Operation,
+				Time:      seconds(c.ElapsedMs),
+			}
+
+			switch {
+			case c.Skipped:
+				suite.Skipped++
+				testCase.Skipped = &junitMessage{Message: "skipped after previous failure"}
+			case c.Error != "":
+				suite.Errors++
+				testCase.Error = &junitMessage{Message: c.Error, Type: "error", Text: c.Error}
+			case !c.Passed:
+				suite.Failures++
+				testCase.Failure = &junitMessage{
+					Message: failureSummary(c.Failures),
+					Type:    "assertion",
+					Text:    failureText(c.Failures),
+				}
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+		}
+
+		suite.Time = seconds(elapsedMs)
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when render junit report, error: %w", err)
+	}
+
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
+
+// failureSummary join names of failed checks
+func failureSummary(failures []Failure) string {
+	names := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		names = append(names, failure.Name)
+	}
+	return strings.Join(names, "; ")
+}
+
+// failureText describe every failed check with diff of expected and actual
+func failureText(failures []Failure) string {
+	var builder strings.Builder
+
+	for _, failure := range failures {
+		builder.WriteString(failure.Name)
+		if failure.Message != "" {
+			builder.WriteString(": " + failure.Message)
+		}
+		builder.WriteString("\n")
+
+		if failure.Expected != nil || failure.Actual != nil {
+			builder.WriteString(Diff(failure.Expected, failure.Actual))
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderMarkdown render summary, one table for each group and details of failures
+func renderMarkdown(report *Report) string {
+	var builder strings.Builder
+
+	name := report.Name
+	if name == "" {
+		name = "Test Report"
+	}
+
+	result := "PASSED"
+	if report.Summary.Failed+report.Summary.Errored+report.Summary.Skipped > 0 {
+		result = "FAILED"
+	}
+
+	fmt.Fprintf(&builder, "# %s\n\n", name)
+	fmt.Fprintf(&builder, "**%s**: %d tests, %d passed, %d failed, %d errored, %d skipped in %ss\n",
+		result, report.Summary.Total, report.Summary.Passed, report.Summary.Failed,
+		report.Summary.Errored, report.Summary.Skipped, seconds(report.ElapsedMs))
+
+	names, groups := report.Groups()
+
+	for _, group := range names {
+		title := group
+		if title == "" {
+			title = "Ungrouped"
+		}
+
+		fmt.Fprintf(&builder, "\n## %s\n\n", escapeCell(title))
+		builder.WriteString("| Result | Test | Operation | Status | Time |\n")
+		builder.WriteString("| --- | --- | --- | --- | --- |\n")
+
+		for _, c := range groups[group] {
+			statusCode := ""
+			if c.StatusCode != 0 {
+				statusCode = fmt.Sprint(c.StatusCode)
+			}
+
+			fmt.Fprintf(&builder, "| %s | %s | `%s` | %s | %dms |\n",
+				caseResult(c), escapeCell(c.Name), escapeCell(c.Operation), statusCode, c.ElapsedMs)
+		}
+	}
+
+	var failed []Case
+	for _, c := range report.Cases {
+		if !c.Passed && !c.Skipped {
+			failed = append(failed, c)
+		}
+	}
+
+	if len(failed) == 0 {
+		return builder.String()
+	}
+
+	builder.WriteString("\n## Failures\n")
+
+	for _, c := range failed {
+		fmt.Fprintf(&builder, "\n### %s\n\n`%s`\n\n", c.Name, c.Operation)
+
+		if c.Error != "" {
+			fmt.Fprintf(&builder, "- **error**: %s\n", c.Error)
+		}
+
+		for _, failure := range c.Failures {
+			fmt.Fprintf(&builder, "- **%s**", failure.Name)
+			if failure.Message != "" {
+				fmt.Fprintf(&builder, ": %s", failure.Message)
+			}
+			builder.WriteString("\n")
+
+			if failure.Expected != nil || failure.Actual != nil {
+				fmt.Fprintf(&builder, "\n```diff\n%s```\n\n", Diff(failure.Expected, failure.Actual))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+func caseResult(c Case) string {
+	switch {
+	case c.Skipped:
+		return "SKIP"
+	case c.Error != "":
+		return "ERROR"
+	case !c.Passed:
+		return "FAIL"
+	}
+	return "PASS"
+}
+
+// escapeCell keep markdown table from breaking by pipe and newline
+func escapeCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", " ")
+}
@@ -0,0 +1,138 @@
+// Package report will render results of test suites, scenarios and stored history
+// into JUnit XML for CI, JSON for machines and Markdown for humans
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format is the output format of report
+type Format string
+
+const (
+	// JUnit is JUnit XML that CI dashboards understand
+	JUnit Format = "junit"
+
+	// JSON is the Report itself encoded in json
+	JSON Format = "json"
+
+	// Markdown is summary grouped by tag or path with failure diffs
+	Markdown Format = "markdown"
+)
+
+// Formats is every format that can be rendered
+var Formats = []Format{JUnit, JSON, Markdown}
+
+// Failure is one failed check of a case
+type Failure struct {
+	Name     string `json:"name" yaml:"name"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+	Expected any    `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// Case is one executed test
+type Case struct {
+	Name       string    `json:"name" yaml:"name"`
+	Group      string    `json:"group" yaml:"group"`         // tag of operation or path if no tag
+	Operation  string    `json:"operation" yaml:"operation"` // like GET /users/{id}
+	Passed     bool      `json:"passed" yaml:"passed"`
+	Skipped    bool      `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	ElapsedMs  int64     `json:"elapsedMs" yaml:"elapsedMs"`
+	StatusCode int       `json:"statusCode,omitempty" yaml:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty" yaml:"error,omitempty"` // request can't be sent or template can't be rendered
+	Failures   []Failure `json:"failures,omitempty" yaml:"failures,omitempty"`
+}
+
+// Summary count cases of report
+type Summary struct {
+	Total   int `json:"total" yaml:"total"`
+	Passed  int `json:"passed" yaml:"passed"`
+	Failed  int `json:"failed" yaml:"failed"`
+	Errored int `json:"errored" yaml:"errored"`
+	Skipped int `json:"skipped" yaml:"skipped"`
+}
+
+// Report is the format independent result of a run
+type Report struct {
+	Name      string    `json:"name" yaml:"name"`
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	ElapsedMs int64     `json:"elapsedMs" yaml:"elapsedMs"`
+	Summary   Summary   `json:"summary" yaml:"summary"`
+	Cases     []Case    `json:"cases" yaml:"cases"`
+}
+
+// New create report of cases and count them
+func New(name string, timestamp time.Time, elapsedMs int64, cases []Case) *Report {
+	report := &Report{
+		Name:      name,
+		Timestamp: timestamp,
+		ElapsedMs: elapsedMs,
+		Cases:     cases,
+	}
+
+	for _, c := range cases {
+		report.Summary.Total++
+		switch {
+		case c.Skipped:
+			report.Summary.Skipped++
+		case c.Error != "":
+			report.Summary.Errored++
+		case !c.Passed:
+			report.Summary.Failed++
+		default:
+			report.Summary.Passed++
+		}
+	}
+
+	return report
+}
+
+// ParseFormat convert name like junit, json, markdown or md into Format
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "junit", "xml":
+		return JUnit, nil
+	case "json":
+		return JSON, nil
+	case "markdown", "md":
+		return Markdown, nil
+	}
+	return "", fmt.Errorf("Report format %q is not supported, please use one of %v", name, Formats)
+}
+
+// Render render report in format
+func Render(report *Report, format Format) ([]byte, error) {
+	switch format {
+	case JUnit:
+		return renderJUnit(report)
+	case JSON:
+		return json.MarshalIndent(report, "", "  ")
+	case Markdown:
+		return []byte(renderMarkdown(report)), nil
+	}
+	return nil, fmt.Errorf("Report format %q is not supported, please use one of %v", format, Formats)
+}
+
+// Groups return cases grouped by Group in order of first appearance
+func (r *Report) Groups() ([]string, map[string][]Case) {
+	var names []string
+	groups := make(map[string][]Case)
+
+	for _, c := range r.Cases {
+		if _, ok := groups[c.Group]; !ok {
+			names = append(names, c.Group)
+		}
+		groups[c.Group] = append(groups[c.Group], c)
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names, groups
+}
@@ -0,0 +1,146 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"mcp-api-tester/storage"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestReport() *Report {
+	return New("users", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1500, []Case{
+		{Name: "create user", Group: "users", Operation: "POST /users", Passed: true, StatusCode: 201, ElapsedMs: 500},
+		{
+			Name: "get user", Group: "users", Operation: "GET /users/{id}", StatusCode: 200, ElapsedMs: 1000,
+			Failures: []Failure{{
+				Name:     "$.name equals Alice",
+				Message:  "got Bob",
+				Expected: map[string]any{"id": 1, "name": "Alice"},
+				Actual:   map[string]any{"id": 1, "name": "Bob"},
+			}},
+		},
+		{Name: "list | orders", Group: "/orders", Operation: "GET /orders", Error: "connection refused"},
+		{Name: "delete user", Group: "users", Operation: "DELETE /users/{id}", Skipped: true},
+	})
+}
+
+func Test_New(t *testing.T) {
+	report := newTestReport()
+
+	if report.Summary != (Summary{Total: 4, Passed: 1, Failed: 1, Errored: 1, Skipped: 1}) {
+		t.Errorf("Cases should be counted, got %+v", report.Summary)
+	}
+
+	names, groups := report.Groups()
+	if len(names) != 2 || names[0] != "/orders" || len(groups["users"]) != 3 {
+		t.Errorf("Cases should be grouped, got %v", names)
+	}
+}
+
+func Test_RenderJUnit(t *testing.T) {
+	content, err := Render(newTestReport(), JUnit)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(content, &suites); err != nil {
+		t.Fatalf("JUnit should be valid xml, got %v", err)
+	}
+
+	if suites.Tests != 4 || suites.Failures != 1 || suites.Errors != 1 || suites.Skipped != 1 || len(suites.Suites) != 2 {
+		t.Errorf("Counts and suites are wrong, got %+v", suites)
+	}
+
+	users := suites.Suites[1]
+	if users.Name != "users" || users.Time != "1.500" || users.Cases[1].Failure == nil || users.Cases[2].Skipped == nil {
+		t.Errorf("Users suite is wrong, got %+v", users)
+	}
+
+	if !strings.Contains(users.Cases[1].Failure.Text, `-   "name": "Alice"`) || !strings.Contains(users.Cases[1].Failure.Text, `+   "name": "Bob"`) {
+		t.Errorf("Failure should contain diff, got %s", users.Cases[1].Failure.Text)
+	}
+}
+
+func Test_RenderMarkdown(t *testing.T) {
+	content, err := Render(newTestReport(), Markdown)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	markdown := string(content)
+
+	for _, expected := range []string{
+		"# users",
+		"**FAILED**: 4 tests, 1 passed, 1 failed, 1 errored, 1 skipped in 1.500s",
+		"## /orders",
+		"| ERROR | list \\| orders | `GET /orders` |  | 0ms |",
+		"| FAIL | get user | `GET /users/{id}` | 200 | 1000ms |",
+		"## Failures",
+		"- **$.name equals Alice**: got Bob",
+		"```diff\n  {\n    \"id\": 1,\n-   \"name\": \"Alice\"\n+   \"name\": \"Bob\"\n  }\n```",
+		"- **error**: connection refused",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Markdown should contain %q, got\n%s", expected, markdown)
+		}
+	}
+}
+
+func Test_FromRecords(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// newest first like storage.Query
+	records := []storage.Record{
+		{Operation: "GET /orders", ErrorMessage: "timeout", CreatedAt: base.Add(time.Minute)},
+		{
+			Operation: "GET /users/{id}", Response: &storage.ResponseSummary{StatusCode: 404, ElapsedMs: 20}, CreatedAt: base,
+			Assertions: []storage.AssertionResult{{Name: "status in [200]", Message: "got status 404"}},
+		},
+	}
+
+	report := FromRecords("run-1", records, func(operation string) []string {
+		if strings.Contains(operation, "/users") {
+			return []string{"users"}
+		}
+		return nil
+	})
+
+	if report.Cases[0].Group != "users" || report.Cases[1].Group != "/orders" || !report.Timestamp.Equal(base) {
+		t.Errorf("Cases should be chronological and grouped by tag or path, got %+v", report.Cases)
+	}
+
+	if report.Cases[0].Failures[0].Message != "got status 404" || report.Cases[1].Error != "timeout" {
+		t.Errorf("Failures and errors should be converted, got %+v", report.Cases)
+	}
+
+	content, err := Render(report, JSON)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var decoded Report
+	if err := json.Unmarshal(content, &decoded); err != nil || decoded.Summary.Total != 2 {
+		t.Errorf("JSON report should be decoded, got %+v, error: %v", decoded.Summary, err)
+	}
+
+	if _, err := ParseFormat("html"); err == nil {
+		t.Errorf("Unknown format should be invalid")
+	}
+}
+
+func Test_DiffTruncate(t *testing.T) {
+	expected := strings.Repeat("same\n", maxDiffLines) + strings.Repeat("old\n", 100000)
+	actual := strings.Repeat("same\n", maxDiffLines) + strings.Repeat("new\n", 100000)
+
+	diff := Diff(expected, actual)
+
+	if lines := strings.Count(diff, "\n"); lines != maxDiffLines+1 {
+		t.Errorf("Diff should only compare first %d lines, got %d lines", maxDiffLines, lines)
+	}
+	if !strings.Contains(diff, "  … 100001 lines truncated\n") {
+		t.Errorf("Truncated lines should be marked, got tail %q", diff[len(diff)-64:])
+	}
+}
@@ -0,0 +1,407 @@
+// Package scenario will run ordered http requests as one test,
+// url, headers and body of every step can refer to result of previous steps by template like {{steps.create.body.id}},
+// values can be extracted into variables by JSONPath or header name and checked by expectations
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"mcp-api-tester/assertion"
+	"mcp-api-tester/environment"
+	jsonpath "mcp-api-tester/jsonPath"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/validator"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// DefaultTimeoutMs will be used when timeoutMs of step is not provided
+const DefaultTimeoutMs = 30000
+
+// Request is the http request of a step, every string can contain template like {{vars.token}}
+type Request struct {
+	Method      string            `json:"method" yaml:"method" jsonschema:"required,description=Http method like GET or POST"`
+	URL         string            `json:"url" yaml:"url" jsonschema:"required,description=Absolute url or path start with / that will be appended to baseURL\\, can use template like /users/{{steps.create.body.id}}"`
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty" jsonschema:"description=Request headers\\, value can use template"`
+	Cookies     map[string]string `json:"cookies,omitempty" yaml:"cookies,omitempty" jsonschema:"description=Cookies\\, value can use template"`
+	QueryParams map[string]string `json:"queryParams,omitempty" yaml:"queryParams,omitempty" jsonschema:"description=Query params\\, value can use template"`
+	Body        any               `json:"body,omitempty" yaml:"body,omitempty" jsonschema:"description=Plain string or json body\\, string that is only one template like {{vars.id}} keep type of the value"`
+	ContentType string            `json:"contentType,omitempty" yaml:"contentType,omitempty" jsonschema:"description=Content-Type of body\\, default is application/json for json body"`
+	TimeoutMs   int               `json:"timeoutMs,omitempty" yaml:"timeoutMs,omitempty" jsonschema:"description=Timeout in milliseconds\\, default is 30000"`
+}
+
+// Step is one request in scenario and what to do with its response
+type Step struct {
+	ID      string `json:"id,omitempty" yaml:"id,omitempty" jsonschema:"description=Name used by later steps like {{steps.create.body.id}}\\, default is step1\\, step2 and so on"`
+	Request `yaml:",inline"`
+
+	Extract      map[string]string     `json:"extract,omitempty" yaml:"extract,omitempty" jsonschema:"description=Variable name to source like $.data.id (JSONPath of body)\\, body.data.id\\, headers.Location or status\\, variables can be used later like {{vars.name}}"`
+	ExpectStatus []int                 `json:"expectStatus,omitempty" yaml:"expectStatus,omitempty" jsonschema:"description=Allowed status codes\\, status below 400 is expected if empty"`
+	Expect       map[string]any        `json:"expect,omitempty" yaml:"expect,omitempty" jsonschema:"description=Source like $.name or headers.Content-Type to expected value\\, value can use template"`
+	Assert       []assertion.Assertion `json:"assert,omitempty" yaml:"assert,omitempty" jsonschema:"description=Assertions like {source: jsonPath\\, path: $.items\\, op: length\\, value: {min: 1}}\\, value can use template"`
+}
+
+// Scenario is ordered steps that share variables
+type Scenario struct {
+	Name              string         `json:"name,omitempty" yaml:"name,omitempty"`
+	BaseURL           string         `json:"baseURL,omitempty" yaml:"baseURL,omitempty"`
+	Variables         map[string]any `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Steps             []Step         `json:"steps" yaml:"steps"`
+	ContinueOnFailure bool           `json:"continueOnFailure,omitempty" yaml:"continueOnFailure,omitempty"`
+
+	Environment *environment.Profile `json:"-" yaml:"-"` // default headers and credentials of every step
+	Document    *openapi.OpenAPI     `json:"-" yaml:"-"` // used by matchesSchema assertion to find operation of step
+}
+
+// Check is the outcome of one expectation or assertion
+type Check = assertion.Result
+
+// StepResult is the trace of one step
+type StepResult struct {
+	ID        string                `json:"id" yaml:"id"`
+	Request   *Request              `json:"request,omitempty" yaml:"request,omitempty"` // request after template rendered
+	Response  *netclient.AIResponse `json:"response,omitempty" yaml:"response,omitempty"`
+	Extracted map[string]any        `json:"extracted,omitempty" yaml:"extracted,omitempty"`
+	Checks    []Check               `json:"checks,omitempty" yaml:"checks,omitempty"`
+	Passed    bool                  `json:"passed" yaml:"passed"`
+	Skipped   bool                  `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Error     string                `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Result is the trace of the whole scenario
+type Result struct {
+	Name      string         `json:"name,omitempty" yaml:"name,omitempty"`
+	Passed    bool           `json:"passed" yaml:"passed"`
+	Steps     []StepResult   `json:"steps" yaml:"steps"`
+	Variables map[string]any `json:"variables" yaml:"variables"`
+	ElapsedMs int64          `json:"elapsedMs" yaml:"elapsedMs"`
+}
+
+// Validate check step ids are unique and every step has method and url
+func (s *Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("Scenario %q has no step", s.Name)
+	}
+
+	ids := make(map[string]bool, len(s.Steps))
+	for i, step := range s.Steps {
+		id := stepID(step, i)
+		if ids[id] {
+			return fmt.Errorf("Step id %q is duplicated", id)
+		}
+		ids[id] = true
+
+		if step.Method == "" || step.URL == "" {
+			return fmt.Errorf("Step %q should have both method and url", id)
+		}
+
+		if err := assertion.Validate(step.Assert); err != nil {
+			return fmt.Errorf("Step %q has invalid assertion, error: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// Run execute steps in order, remaining steps will be skipped after a failure unless ContinueOnFailure is set
+func Run(ctx context.Context, scenario Scenario) *Result {
+	start := time.Now()
+	current := newState(scenario.Variables)
+
+	result := &Result{
+		Name:   scenario.Name,
+		Passed: true,
+		Steps:  make([]StepResult, 0, len(scenario.Steps)),
+	}
+
+	stopped := false
+
+	for i, step := range scenario.Steps {
+		id := stepID(step, i)
+
+		if stopped {
+			result.Steps = append(result.Steps, StepResult{ID: id, Skipped: true})
+			continue
+		}
+
+		stepResult := runStep(ctx, scenario, id, step, current)
+		result.Steps = append(result.Steps, stepResult)
+
+		if !stepResult.Passed {
+			result.Passed = false
+			stopped = !scenario.ContinueOnFailure || ctx.Err() != nil
+		}
+	}
+
+	result.Variables = current.variables
+	result.ElapsedMs = time.Since(start).Milliseconds()
+
+	return result
+}
+
+func runStep(ctx context.Context, scenario Scenario, id string, step Step, current *state) StepResult {
+	stepResult := StepResult{ID: id}
+
+	if err := ctx.Err(); err != nil {
+		stepResult.Error = fmt.Sprintf("Scenario is canceled, error: %v", err)
+		return stepResult
+	}
+
+	request, err := render(step.Request, scenario.BaseURL, current)
+	if err != nil {
+		stepResult.Error = err.Error()
+		return stepResult
+	}
+	stepResult.Request = request
+
+	aiRequest, err := request.ToAIRequest()
+	if err != nil {
+		stepResult.Error = err.Error()
+		return stepResult
+	}
+	scenario.Environment.Apply(aiRequest)
+
+	response, err := aiRequest.Do(ctx)
+	if err != nil {
+		stepResult.Error = fmt.Sprintf("Error happened when send %s request to %q, error: %v", aiRequest.Method, aiRequest.URL, err)
+		return stepResult
+	}
+	stepResult.Response = response
+
+	stepContext := newStepContext(response)
+	current.steps[id] = stepContext
+
+	stepResult.Extracted = map[string]any{}
+	for _, name := range validator.SortedKeys(step.Extract) {
+		value, err := stepValue(stepContext, step.Extract[name])
+		if err != nil {
+			stepResult.Error = fmt.Sprintf("Error happened when extract %q, error: %v", name, err)
+			return stepResult
+		}
+
+		stepResult.Extracted[name] = value
+		current.variables[name] = value
+	}
+
+	stepResult.Checks = checkStep(step, response, stepContext, current)
+	stepResult.Checks = append(stepResult.Checks, assertStep(scenario, step, request, response, current)...)
+
+	stepResult.Passed = true
+	for _, check := range stepResult.Checks {
+		stepResult.Passed = stepResult.Passed && check.Passed
+	}
+
+	return stepResult
+}
+
+// render replace templates in request and prepend baseURL to path
+func render(request Request, baseURL string, current *state) (*Request, error) {
+	rendered := request
+
+	var err error
+	if rendered.URL, err = current.renderString(request.URL); err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(rendered.URL, "/") && baseURL != "" {
+		rendered.URL = strings.TrimRight(baseURL, "/") + rendered.URL
+	}
+
+	if rendered.Headers, err = current.renderMap(request.Headers); err != nil {
+		return nil, err
+	}
+
+	if rendered.Cookies, err = current.renderMap(request.Cookies); err != nil {
+		return nil, err
+	}
+
+	if rendered.QueryParams, err = current.renderMap(request.QueryParams); err != nil {
+		return nil, err
+	}
+
+	if rendered.Body, err = current.renderValue(request.Body); err != nil {
+		return nil, err
+	}
+
+	return &rendered, nil
+}
+
+// ToAIRequest convert Request into netclient.AIRequest
+func (r *Request) ToAIRequest() (*netclient.AIRequest, error) {
+	body, contentType, err := netclient.EncodeBody(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.ContentType != "" {
+		contentType = r.ContentType
+	}
+
+	timeoutMs := r.TimeoutMs
+	if timeoutMs <= 0 {
+		timeoutMs = DefaultTimeoutMs
+	}
+
+	return &netclient.AIRequest{
+		Method:      strings.ToUpper(r.Method),
+		URL:         r.URL,
+		Headers:     r.Headers,
+		Cookies:     r.Cookies,
+		QueryParams: r.QueryParams,
+		Body:        body,
+		ContentType: contentType,
+		TimeoutMs:   time.Duration(timeoutMs) * time.Millisecond,
+	}, nil
+}
+
+// checkStep check status and every expectation of step
+func checkStep(step Step, response *netclient.AIResponse, stepContext map[string]any, current *state) []Check {
+	checks := make([]Check, 0, len(step.Expect)+1)
+
+	statusCheck := Check{Name: "status", Passed: true}
+	if len(step.ExpectStatus) > 0 {
+		statusCheck.Name = fmt.Sprintf("status in %v", step.ExpectStatus)
+		statusCheck.Passed = slices.Contains(step.ExpectStatus, response.StatusCode)
+	} else {
+		statusCheck.Name = "status below 400"
+		statusCheck.Passed = response.StatusCode < 400
+	}
+	if !statusCheck.Passed {
+		statusCheck.Message = fmt.Sprintf("got status %d", response.StatusCode)
+		statusCheck.Expected = step.ExpectStatus
+		statusCheck.Actual = response.StatusCode
+	}
+	checks = append(checks, statusCheck)
+
+	for _, source := range validator.SortedKeys(step.Expect) {
+		check := Check{Name: source + " equals"}
+
+		expected, err := current.renderValue(step.Expect[source])
+		if err != nil {
+			check.Message = err.Error()
+			checks = append(checks, check)
+			continue
+		}
+		check.Name = fmt.Sprintf("%s equals %s", source, stringify(expected))
+
+		actual, err := stepValue(stepContext, source)
+		switch {
+		case err != nil:
+			check.Message = err.Error()
+		case !equalJSON(actual, expected):
+			check.Message = fmt.Sprintf("got %s", stringify(actual))
+			check.Expected = expected
+			check.Actual = actual
+		default:
+			check.Passed = true
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// assertStep evaluate assertions of step after their values are rendered
+func assertStep(scenario Scenario, step Step, request *Request, response *netclient.AIResponse, current *state) []Check {
+	if len(step.Assert) == 0 {
+		return nil
+	}
+
+	assertions := make([]assertion.Assertion, 0, len(step.Assert))
+	checks := []Check{}
+
+	for _, a := range step.Assert {
+		value, err := current.renderValue(a.Value)
+		if err != nil {
+			checks = append(checks, Check{Name: a.Source + " " + a.Op, Message: err.Error()})
+			continue
+		}
+		a.Value = value
+		assertions = append(assertions, a)
+	}
+
+	var operation *v3high.Operation
+	if assertion.NeedSchema(assertions) && scenario.Document != nil {
+		if parsedURL, err := url.Parse(request.URL); err == nil {
+			if matched, err := scenario.Document.FindOperation(request.Method, parsedURL.Path); err == nil {
+				operation = matched.Operation
+			}
+		}
+	}
+
+	return append(checks, assertion.Evaluate(assertions, response, operation)...)
+}
+
+// newStepContext is what {{steps.<id>...}} refer to
+func newStepContext(response *netclient.AIResponse) map[string]any {
+	headers := make(map[string]any, len(response.Headers))
+	for key, value := range response.Headers {
+		headers[http.CanonicalHeaderKey(key)] = value
+	}
+
+	return map[string]any{
+		"status":  float64(response.StatusCode),
+		"headers": headers,
+		"body":    response.Body,
+	}
+}
+
+// stepValue read value from step by source like $.id (JSONPath of body), body.id, headers.Location or status
+func stepValue(stepContext map[string]any, source string) (any, error) {
+	source = strings.TrimSpace(source)
+
+	root := any(stepContext)
+	path := source
+
+	if strings.HasPrefix(source, "$") {
+		root = stepContext["body"]
+	} else if name, ok := strings.CutPrefix(source, "headers."); ok {
+		path = "headers." + http.CanonicalHeaderKey(name)
+	}
+
+	value, ok, err := jsonpath.Get(root, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%q is not found in response", source)
+	}
+
+	return value, nil
+}
+
+// equalJSON compare values after json round trip so 1 and 1.0 are equal
+func equalJSON(a any, b any) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(value any) any {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(valueBytes, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func stepID(step Step, index int) string {
+	if step.ID != "" {
+		return step.ID
+	}
+	return fmt.Sprintf("step%d", index+1)
+}
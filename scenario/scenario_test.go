@@ -0,0 +1,187 @@
+package scenario
+
+import (
+	"encoding/json"
+	"io"
+	"mcp-api-tester/assertion"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newUserServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	users := map[string]map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/users":
+			var user map[string]any
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &user); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			user["id"] = 42.0
+			users["42"] = user
+			w.Header().Set("Location", "/users/42")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(user)
+		case strings.HasPrefix(r.URL.Path, "/users/"):
+			id := strings.TrimPrefix(r.URL.Path, "/users/")
+			user, ok := users[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.Method == http.MethodDelete {
+				delete(users, id)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(user)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_Run(t *testing.T) {
+	server := newUserServer(t)
+
+	s := Scenario{
+		Name:      "user lifecycle",
+		BaseURL:   server.URL,
+		Variables: map[string]any{"name": "Alice", "age": 30.0},
+		Steps: []Step{
+			{
+				ID:           "create",
+				Request:      Request{Method: "post", URL: "/users", Body: map[string]any{"name": "{{name}}", "age": "{{vars.age}}"}},
+				Extract:      map[string]string{"userId": "$.id", "location": "headers.location"},
+				ExpectStatus: []int{201},
+				Expect:       map[string]any{"$.name": "Alice", "$.age": 30},
+			},
+			{
+				ID:      "get",
+				Request: Request{Method: "GET", URL: "{{vars.location}}"},
+				Expect:  map[string]any{"body.id": "{{steps.create.body.id}}"},
+			},
+			{
+				Request:      Request{Method: "DELETE", URL: "/users/{{userId}}"},
+				ExpectStatus: []int{204},
+			},
+			{
+				ID:           "gone",
+				Request:      Request{Method: "GET", URL: "/users/{{steps.create.body.id}}"},
+				ExpectStatus: []int{404},
+			},
+		},
+	}
+
+	if err := s.Validate(); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	result := Run(t.Context(), s)
+	if !result.Passed {
+		resultBytes, _ := json.MarshalIndent(result, "", "  ")
+		t.Fatalf("Scenario should pass, got %s", resultBytes)
+	}
+
+	if result.Variables["userId"] != 42.0 || result.Variables["location"] != "/users/42" {
+		t.Errorf("Variables should be extracted, got %v", result.Variables)
+	}
+
+	if result.Steps[2].ID != "step3" || result.Steps[2].Request.URL != server.URL+"/users/42" {
+		t.Errorf("Default id and rendered url should be used, got %q %q", result.Steps[2].ID, result.Steps[2].Request.URL)
+	}
+
+	if body, ok := result.Steps[0].Request.Body.(map[string]any); !ok || body["age"] != 30.0 {
+		t.Errorf("Single template should keep type of value, got %v", result.Steps[0].Request.Body)
+	}
+}
+
+func Test_RunStopOnFailure(t *testing.T) {
+	server := newUserServer(t)
+
+	s := Scenario{
+		BaseURL: server.URL,
+		Steps: []Step{
+			{ID: "missing", Request: Request{Method: "GET", URL: "/users/1"}},
+			{ID: "next", Request: Request{Method: "GET", URL: "/users/{{steps.missing.body.id}}"}},
+		},
+	}
+
+	result := Run(t.Context(), s)
+	if result.Passed || result.Steps[0].Passed || !result.Steps[1].Skipped {
+		t.Errorf("Step after failure should be skipped, got %+v", result.Steps)
+	}
+
+	s.ContinueOnFailure = true
+	result = Run(t.Context(), s)
+	if result.Steps[1].Skipped || !strings.Contains(result.Steps[1].Error, "steps.missing.body.id") {
+		t.Errorf("Step should run and fail on unresolved template, got %+v", result.Steps[1])
+	}
+}
+
+func Test_RunAssert(t *testing.T) {
+	server := newUserServer(t)
+
+	s := Scenario{
+		BaseURL:   server.URL,
+		Variables: map[string]any{"name": "Bob"},
+		Steps: []Step{
+			{
+				ID:      "create",
+				Request: Request{Method: "POST", URL: "/users", Body: map[string]any{"name": "{{vars.name}}"}},
+				Assert: []assertion.Assertion{
+					{Source: assertion.SourceStatus, Op: assertion.OpRange, Value: "2xx"},
+					{Source: assertion.SourceHeader, Path: "Location", Op: assertion.OpMatches, Value: `^/users/\d+$`},
+					{Source: assertion.SourceJSONPath, Path: "$.name", Op: assertion.OpEquals, Value: "{{vars.name}}"},
+					{Source: assertion.SourceJSONPath, Path: "$.id", Op: assertion.OpType, Value: "string"},
+				},
+			},
+		},
+	}
+
+	result := Run(t.Context(), s)
+
+	checks := result.Steps[0].Checks
+	if len(checks) != 5 {
+		t.Fatalf("expected status check and 4 assertions, got %+v", checks)
+	}
+	for i, passed := range []bool{true, true, true, true, false} {
+		if checks[i].Passed != passed {
+			t.Errorf("check %q expected passed %v, got %+v", checks[i].Name, passed, checks[i])
+		}
+	}
+	if result.Passed {
+		t.Errorf("Scenario should fail when assertion failed")
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	s := Scenario{Steps: []Step{
+		{ID: "a", Request: Request{Method: "GET", URL: "/"}},
+		{ID: "a", Request: Request{Method: "GET", URL: "/"}},
+	}}
+
+	if err := s.Validate(); err == nil {
+		t.Errorf("Duplicated id should be invalid")
+	}
+
+	s = Scenario{Steps: []Step{
+		{Request: Request{Method: "GET", URL: "/"}, Assert: []assertion.Assertion{{Source: "status", Op: "below", Value: 1}}},
+	}}
+
+	if err := s.Validate(); err == nil {
+		t.Errorf("Unsupported op of assertion should be invalid")
+	}
+}
@@ -0,0 +1,171 @@
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	jsonpath "mcp-api-tester/jsonPath"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// templatePattern match placeholder like {{steps.create.body.id}} or {{userId}}
+var templatePattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// state is everything that template can refer to
+type state struct {
+	steps     map[string]any // step id to {status, headers, body}
+	variables map[string]any
+}
+
+func newState(variables map[string]any) *state {
+	s := &state{
+		steps:     map[string]any{},
+		variables: map[string]any{},
+	}
+
+	for key, value := range variables {
+		s.variables[key] = value
+	}
+
+	return s
+}
+
+// root is the json value that expression in template is evaluated on
+func (s *state) root() map[string]any {
+	return map[string]any{
+		"steps": s.steps,
+		"vars":  s.variables,
+	}
+}
+
+// resolve evaluate expression like steps.create.body.id, vars.userId, userId or $.steps.create.status
+func (s *state) resolve(expression string) (any, error) {
+	expression = strings.TrimSpace(expression)
+
+	if !strings.HasPrefix(expression, "$") && !strings.HasPrefix(expression, "steps.") && !strings.HasPrefix(expression, "vars.") {
+		value, ok := s.variables[expression]
+		if !ok {
+			return nil, fmt.Errorf("variable %q is not defined", expression)
+		}
+		return value, nil
+	}
+
+	value, ok, err := jsonpath.Get(s.root(), canonicalStepHeader(expression))
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%q is not found", expression)
+	}
+
+	return value, nil
+}
+
+// canonicalStepHeader make header name case-insensitive in expression like steps.create.headers.location
+func canonicalStepHeader(expression string) string {
+	parts := strings.SplitN(strings.TrimPrefix(expression, "$."), ".", 4)
+	if len(parts) == 4 && parts[0] == "steps" && parts[2] == "headers" {
+		parts[3] = http.CanonicalHeaderKey(parts[3])
+		return strings.Join(parts, ".")
+	}
+	return expression
+}
+
+// renderString replace every placeholder in text, value will be converted into string
+func (s *state) renderString(text string) (string, error) {
+	var renderErr error
+
+	rendered := templatePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		expression := templatePattern.FindStringSubmatch(placeholder)[1]
+
+		value, err := s.resolve(expression)
+		if err != nil {
+			if renderErr == nil {
+				renderErr = fmt.Errorf("Error happened when render %q, error: %w", placeholder, err)
+			}
+			return placeholder
+		}
+
+		return stringify(value)
+	})
+
+	return rendered, renderErr
+}
+
+// renderValue render every string in json value, string that is only one placeholder
+// will be replaced by the value itself so number and object keep their type
+func (s *state) renderValue(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if match := templatePattern.FindStringSubmatch(v); match != nil && match[0] == strings.TrimSpace(v) {
+			resolved, err := s.resolve(match[1])
+			if err != nil {
+				return nil, fmt.Errorf("Error happened when render %q, error: %w", v, err)
+			}
+			return resolved, nil
+		}
+		return s.renderString(v)
+	case map[string]any:
+		rendered := make(map[string]any, len(v))
+		for key, item := range v {
+			renderedItem, err := s.renderValue(item)
+			if err != nil {
+				return nil, err
+			}
+			rendered[key] = renderedItem
+		}
+		return rendered, nil
+	case []any:
+		rendered := make([]any, 0, len(v))
+		for _, item := range v {
+			renderedItem, err := s.renderValue(item)
+			if err != nil {
+				return nil, err
+			}
+			rendered = append(rendered, renderedItem)
+		}
+		return rendered, nil
+	}
+
+	return value, nil
+}
+
+func (s *state) renderMap(values map[string]string) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	rendered := make(map[string]string, len(values))
+	for key, value := range values {
+		renderedValue, err := s.renderString(value)
+		if err != nil {
+			return nil, err
+		}
+		rendered[key] = renderedValue
+	}
+
+	return rendered, nil
+}
+
+// stringify convert json value into string that can be put in url or header
+func stringify(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(valueBytes)
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter choose records in Query, empty field means no filter
+type Filter struct {
+	Spec       string
+	Operation  string // like GET /users/{id}, method can be omitted like /users/{id}
+	RunID      string
+	Since      time.Time
+	Until      time.Time
+	Passed     *bool
+	StatusCode string // exact code like 404 or class like 4xx
+	Limit      int
+}
+
+// ParseTime parse since or until of filter in RFC3339, empty value means no limit
+func ParseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Time %q should be RFC3339 like 2024-01-01T00:00:00Z, error: %w", value, err)
+	}
+
+	return parsed, nil
+}
+
+// Match return true if record pass every condition of filter
+func (f Filter) Match(record Record) bool {
+	if f.Spec != "" && f.Spec != record.Spec {
+		return false
+	}
+
+	if f.Operation != "" && !matchOperation(f.Operation, record.Operation) {
+		return false
+	}
+
+	if f.RunID != "" && f.RunID != record.RunID {
+		return false
+	}
+
+	if !f.Since.IsZero() && record.CreatedAt.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && record.CreatedAt.After(f.Until) {
+		return false
+	}
+
+	if f.Passed != nil && *f.Passed != record.Passed {
+		return false
+	}
+
+	if f.StatusCode != "" && !matchStatusCode(f.StatusCode, record.Response) {
+		return false
+	}
+
+	return true
+}
+
+// matchOperation compare operation key, path only key match every method
+func matchOperation(want string, operation string) bool {
+	if !strings.Contains(want, " ") {
+		_, path, _ := strings.Cut(operation, " ")
+		return path == want
+	}
+
+	wantMethod, wantPath, _ := strings.Cut(want, " ")
+	return OperationKey(wantMethod, wantPath) == operation
+}
+
+func matchStatusCode(want string, response *ResponseSummary) bool {
+	if response == nil {
+		return false
+	}
+
+	code := strconv.Itoa(response.StatusCode)
+
+	if len(want) == 3 && strings.EqualFold(want[1:], "xx") {
+		return code[:1] == want[:1]
+	}
+
+	return code == want
+}
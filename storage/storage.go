@@ -0,0 +1,183 @@
+// Package storage will persist executed test results into JSONL file
+// so findings of every run can be queried later by endpoint, time and status
+package storage
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	netclient "mcp-api-tester/net/client"
+	"mcp-api-tester/redact"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultsFileName is the file under data directory that store every record
+const ResultsFileName = "test-results.jsonl"
+
+// Results is the store used by mcp tools, it can be replaced by -data-dir flag
+var Results = NewStore(DefaultDir())
+
+// AssertionResult is the outcome of one check applied to response
+type AssertionResult struct {
+	Name     string `json:"name" yaml:"name"`
+	Passed   bool   `json:"passed" yaml:"passed"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+	Expected any    `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Actual   any    `json:"actual,omitempty" yaml:"actual,omitempty"`
+}
+
+// ResponseSummary is the part of response worth keeping
+type ResponseSummary struct {
+	StatusCode int   `json:"statusCode" yaml:"statusCode"`
+	ElapsedMs  int64 `json:"elapsedMs,omitempty" yaml:"elapsedMs,omitempty"`
+	Size       int   `json:"size,omitempty" yaml:"size,omitempty"`
+	Body       any   `json:"body,omitempty" yaml:"body,omitempty"`
+
+	Timing *netclient.Timing `json:"timing,omitempty" yaml:"timing,omitempty"` // where time of request went
+}
+
+// Record is one executed request and its result
+type Record struct {
+	ID           string            `json:"id" yaml:"id"`
+	RunID        string            `json:"runId,omitempty" yaml:"runId,omitempty"`
+	Spec         string            `json:"spec,omitempty" yaml:"spec,omitempty"`         // source of OpenAPI document
+	Operation    string            `json:"operation" yaml:"operation"`                   // like GET /users/{id}
+	URL          string            `json:"url,omitempty" yaml:"url,omitempty"`           // real url that was called
+	Request      any               `json:"request,omitempty" yaml:"request,omitempty"`   // anything that describe the request
+	Response     *ResponseSummary  `json:"response,omitempty" yaml:"response,omitempty"` // nil if request failed before response
+	Assertions   []AssertionResult `json:"assertions,omitempty" yaml:"assertions,omitempty"`
+	Passed       bool              `json:"passed" yaml:"passed"`
+	ErrorMessage string            `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt" yaml:"createdAt"`
+}
+
+// OperationKey build key of operation like GET /users/{id}
+func OperationKey(method string, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// Store append records into JSONL file under directory
+type Store struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewStore create store that write into dir, dir will be created when first record is saved
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir return mcp-api-tester directory under user cache directory,
+// .mcp-api-tester in working directory will be used if cache directory is unknown
+func DefaultDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ".mcp-api-tester"
+	}
+	return filepath.Join(cacheDir, "mcp-api-tester")
+}
+
+// Dir return directory of store
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Append save record, ID and CreatedAt will be filled if empty
+func (s *Store) Append(record Record) (Record, error) {
+	if record.ID == "" {
+		record.ID = NewID()
+	}
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return record, fmt.Errorf("Error happened when encode test result, error: %w", err)
+	}
+	line = redact.Default.JSON(line)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return record, fmt.Errorf("Error happened when create data directory %q, error: %w", s.dir, err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(s.dir, ResultsFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return record, fmt.Errorf("Error happened when open test result file, error: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return record, fmt.Errorf("Error happened when write test result, error: %w", err)
+	}
+
+	return record, nil
+}
+
+// Query return records match filter, newest first
+func (s *Store) Query(filter Filter) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(filepath.Join(s.dir, ResultsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Record{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when open test result file, error: %w", err)
+	}
+	defer file.Close()
+
+	records := []Record{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		var record Record
+
+		// broken line like half written one should not stop the whole query
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error happened when read test result file, error: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[:filter.Limit]
+	}
+
+	return records, nil
+}
+
+// NewID return random hex id
+func NewID() string {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(bytes)
+}
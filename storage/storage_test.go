@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_Store(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "data"))
+
+	records, err := store.Query(Filter{})
+	if err != nil || len(records) != 0 {
+		t.Fatalf("Query before any record should be empty, got %v %v", records, err)
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	inputs := []Record{
+		{RunID: "run-1", Spec: "a.yaml", Operation: "GET /users/{id}", Passed: true, Response: &ResponseSummary{StatusCode: 200}, CreatedAt: base},
+		{RunID: "run-1", Spec: "a.yaml", Operation: "DELETE /users/{id}", Passed: false, Response: &ResponseSummary{StatusCode: 500}, CreatedAt: base.Add(time.Hour)},
+		{RunID: "run-2", Spec: "a.yaml", Operation: "GET /users/{id}", Passed: false, Response: &ResponseSummary{StatusCode: 404}, CreatedAt: base.Add(2 * time.Hour)},
+		{RunID: "run-2", Spec: "b.yaml", Operation: "GET /orders", Passed: false, ErrorMessage: "connection refused", CreatedAt: base.Add(3 * time.Hour)},
+	}
+
+	for _, input := range inputs {
+		record, err := store.Append(input)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		if record.ID == "" {
+			t.Errorf("ID should be generated")
+		}
+	}
+
+	// Broken line should be skipped
+	file, err := os.OpenFile(filepath.Join(store.Dir(), ResultsFileName), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	_, _ = file.WriteString("{broken\n")
+	file.Close()
+
+	failed := false
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string // operations newest first
+	}{
+		{"all", Filter{}, []string{"GET /orders", "GET /users/{id}", "DELETE /users/{id}", "GET /users/{id}"}},
+		{"operation", Filter{Operation: "GET /users/{id}"}, []string{"GET /users/{id}", "GET /users/{id}"}},
+		{"path only", Filter{Operation: "/users/{id}"}, []string{"GET /users/{id}", "DELETE /users/{id}", "GET /users/{id}"}},
+		{"run", Filter{RunID: "run-1"}, []string{"DELETE /users/{id}", "GET /users/{id}"}},
+		{"spec", Filter{Spec: "b.yaml"}, []string{"GET /orders"}},
+		{"failed", Filter{Passed: &failed, Spec: "a.yaml"}, []string{"GET /users/{id}", "DELETE /users/{id}"}},
+		{"status class", Filter{StatusCode: "4xx"}, []string{"GET /users/{id}"}},
+		{"status code", Filter{StatusCode: "500"}, []string{"DELETE /users/{id}"}},
+		{"time range", Filter{Since: base.Add(30 * time.Minute), Until: base.Add(2 * time.Hour)}, []string{"GET /users/{id}", "DELETE /users/{id}"}},
+		{"limit", Filter{Limit: 1}, []string{"GET /orders"}},
+	}
+
+	for _, test := range tests {
+		records, err := store.Query(test.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		operations := make([]string, 0, len(records))
+		for _, record := range records {
+			operations = append(operations, record.Operation)
+		}
+
+		if len(operations) != len(test.want) {
+			t.Errorf("%s: should return %v, got %v", test.name, test.want, operations)
+			continue
+		}
+
+		for i := range operations {
+			if operations[i] != test.want[i] {
+				t.Errorf("%s: should return %v, got %v", test.name, test.want, operations)
+				break
+			}
+		}
+	}
+}
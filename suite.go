@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/report"
+	testsuite "mcp-api-tester/testSuite"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// runSuiteCommand run test suite without mcp client,
+// usage: mcp-api-tester run -suite suite.yaml [-base-url http://localhost:8080] [-spec openapi.yaml] [-env-file env.yaml -env staging] [-report junit -report-file report.xml]
+// exit code is 1 when any test failed and 2 when suite can't be run
+func runSuiteCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var suitePath string
+	flags.StringVar(&suitePath, "suite", "", "Path of YAML or JSON test-suite file")
+
+	var baseURL string
+	flags.StringVar(&baseURL, "base-url", "", "Base url that override the one in suite file")
+
+	var specPath string
+	flags.StringVar(&specPath, "spec", "", "Path or url of OpenAPI file that override the one in suite file")
+
+	var envFile string
+	flags.StringVar(&envFile, "env-file", "", "YAML or JSON file of environments that provide base url, headers, variables and auth")
+
+	var envName string
+	flags.StringVar(&envName, "env", "", "Name of environment in -env-file, default of file will be used if empty")
+
+	var reportFormat string
+	flags.StringVar(&reportFormat, "report", "", "Report format junit, json or markdown, printed instead of plain text if -report-file is empty")
+
+	var reportFile string
+	flags.StringVar(&reportFile, "report-file", "", "File that report will be written to")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var format report.Format
+	if reportFormat != "" {
+		var err error
+		if format, err = report.ParseFormat(reportFormat); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	} else if reportFile != "" {
+		fmt.Fprintln(stderr, "-report is required when -report-file is given")
+		return 2
+	}
+
+	if suitePath == "" {
+		fmt.Fprintln(stderr, "-suite is required")
+		flags.Usage()
+		return 2
+	}
+
+	suite, err := testsuite.Load(suitePath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	config, err := loadEnvironment(envFile, envName)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	options := testsuite.Options{BaseURL: baseURL}
+	if config != nil {
+		options.Environment = config.Environments[config.Default]
+	}
+	if specPath != "" {
+		options.Document, err = openapi.ReadFromPath(specPath)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	startedAt := time.Now()
+
+	result, err := testsuite.Run(ctx, suite, options)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	if format == "" || reportFile != "" {
+		printSuiteResult(stdout, result)
+	}
+
+	if format != "" {
+		content, err := report.Render(report.FromSuite(result, startedAt), format)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+
+		if reportFile == "" {
+			_, _ = stdout.Write(content)
+		} else if err := os.WriteFile(reportFile, content, 0o644); err != nil {
+			fmt.Fprintf(stderr, "Error happened when write report to %q, error: %v\n", reportFile, err)
+			return 2
+		}
+	}
+
+	if !result.Passed {
+		return 1
+	}
+	return 0
+}
+
+// printSuiteResult print one line for each test and failed checks under it
+func printSuiteResult(w io.Writer, result *testsuite.Result) {
+	for _, test := range result.Tests {
+		status := "PASS"
+		switch {
+		case test.Skipped:
+			status = "SKIP"
+		case !test.Passed:
+			status = "FAIL"
+		}
+
+		fmt.Fprintf(w, "%s  %s (%s)\n", status, test.Name, test.Operation)
+
+		if test.Error != "" {
+			fmt.Fprintf(w, "      error: %s\n", test.Error)
+		}
+
+		for _, check := range test.Checks {
+			if !check.Passed {
+				fmt.Fprintf(w, "      %s: %s\n", check.Name, check.Message)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d tests, %d passed, %d failed, %d skipped in %dms\n",
+		result.Summary.Total, result.Summary.Passed, result.Summary.Failed, result.Summary.Skipped, result.ElapsedMs)
+}
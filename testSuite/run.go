@@ -0,0 +1,253 @@
+package testsuite
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/environment"
+	"mcp-api-tester/generator"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/scenario"
+	"mcp-api-tester/validator"
+	"strings"
+)
+
+// Options change how suite is run
+type Options struct {
+	BaseURL     string               // override baseURL of suite
+	Document    *openapi.OpenAPI     // used instead of reading spec of suite
+	Environment *environment.Profile // provide base url, variables, headers and credentials that suite doesn't set
+}
+
+// TestResult is the result of one test
+type TestResult struct {
+	Name                string   `json:"name" yaml:"name"`
+	Operation           string   `json:"operation" yaml:"operation"` // like GET /users/{id}
+	Tags                []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	scenario.StepResult `yaml:",inline"`
+}
+
+// Summary count results of tests
+type Summary struct {
+	Total   int `json:"total" yaml:"total"`
+	Passed  int `json:"passed" yaml:"passed"`
+	Failed  int `json:"failed" yaml:"failed"`
+	Skipped int `json:"skipped" yaml:"skipped"`
+}
+
+// Result is the result of whole suite
+type Result struct {
+	Name      string       `json:"name" yaml:"name"`
+	Passed    bool         `json:"passed" yaml:"passed"`
+	Summary   Summary      `json:"summary" yaml:"summary"`
+	ElapsedMs int64        `json:"elapsedMs" yaml:"elapsedMs"`
+	Tests     []TestResult `json:"tests" yaml:"tests"`
+}
+
+// Run execute every test in order, tests share variables so later test can use result of earlier one
+func Run(ctx context.Context, suite *Suite, options Options) (*Result, error) {
+	document := options.Document
+	if document == nil && suite.Spec != "" {
+		var err error
+		document, err = openapi.ReadFromPath(suite.Spec)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseURL := options.BaseURL
+	if baseURL == "" {
+		baseURL = suite.BaseURL
+	}
+	if baseURL == "" && options.Environment != nil {
+		baseURL = options.Environment.BaseURL
+	}
+	if baseURL == "" && document != nil {
+		baseURL = document.DefaultServerURL()
+	}
+
+	s := scenario.Scenario{
+		Name:              suite.Name,
+		BaseURL:           baseURL,
+		Variables:         map[string]any{},
+		Steps:             make([]scenario.Step, 0, len(suite.Tests)),
+		ContinueOnFailure: !suite.StopOnFailure,
+		Environment:       options.Environment,
+		Document:          document,
+	}
+
+	if options.Environment != nil {
+		for name, value := range options.Environment.Variables {
+			s.Variables[name] = value
+		}
+	}
+	for name, value := range suite.Variables {
+		s.Variables[name] = value
+	}
+
+	matchedOperations := make([]*openapi.MatchedOperation, 0, len(suite.Tests))
+
+	for i, test := range suite.Tests {
+		matched, err := resolveOperation(document, test)
+		if err != nil {
+			return nil, fmt.Errorf("Error happened when resolve operation of test %d %q, error: %w", i+1, test.Name, err)
+		}
+
+		if test.ValidateSchema && matched.Operation == nil {
+			return nil, fmt.Errorf("Test %d %q need spec to validate schema", i+1, test.Name)
+		}
+
+		matchedOperations = append(matchedOperations, matched)
+		s.Steps = append(s.Steps, toStep(suite, test, matched))
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	scenarioResult := scenario.Run(ctx, s)
+
+	result := &Result{
+		Name:      suite.Name,
+		Passed:    true,
+		ElapsedMs: scenarioResult.ElapsedMs,
+		Tests:     make([]TestResult, 0, len(suite.Tests)),
+	}
+
+	for i, stepResult := range scenarioResult.Steps {
+		test := suite.Tests[i]
+		matched := matchedOperations[i]
+
+		if test.ValidateSchema && stepResult.Response != nil {
+			stepResult.Checks = append(stepResult.Checks, schemaCheck(matched, stepResult))
+			stepResult.Passed = stepResult.Error == "" && allPassed(stepResult.Checks)
+		}
+
+		testResult := TestResult{
+			Name:       test.Name,
+			Operation:  strings.ToUpper(matched.Method) + " " + matched.Path,
+			StepResult: stepResult,
+		}
+
+		if testResult.Name == "" {
+			testResult.Name = test.operationRef()
+		}
+
+		if matched.Operation != nil {
+			testResult.Tags = matched.Operation.Tags
+		}
+
+		result.Summary.Total++
+		switch {
+		case stepResult.Skipped:
+			result.Summary.Skipped++
+		case stepResult.Passed:
+			result.Summary.Passed++
+		default:
+			result.Summary.Failed++
+		}
+
+		result.Tests = append(result.Tests, testResult)
+	}
+
+	result.Passed = result.Summary.Failed == 0 && result.Summary.Skipped == 0
+
+	return result, nil
+}
+
+// resolveOperation find operation that test refer to, Operation of result will be nil if suite has no spec
+func resolveOperation(document *openapi.OpenAPI, test Test) (*openapi.MatchedOperation, error) {
+	method, path := test.Method, test.Path
+
+	if test.Operation != "" {
+		var ok bool
+		method, path, ok = strings.Cut(strings.TrimSpace(test.Operation), " ")
+		if !ok {
+			return nil, fmt.Errorf("Operation %q should be like GET /users/{id}", test.Operation)
+		}
+		path = strings.TrimSpace(path)
+	}
+
+	if test.OperationID != "" {
+		if document == nil {
+			return nil, fmt.Errorf("OperationId %q need spec", test.OperationID)
+		}
+		return document.FindOperationByID(test.OperationID)
+	}
+
+	if method == "" || path == "" {
+		return nil, fmt.Errorf("Test should have operation, operationId or path with method")
+	}
+
+	if document == nil {
+		return &openapi.MatchedOperation{
+			Path:   path,
+			Method: strings.ToLower(method),
+		}, nil
+	}
+
+	return document.GetMatchedOperation(path, method)
+}
+
+// toStep convert test into scenario step with path params filled
+func toStep(suite *Suite, test Test, matched *openapi.MatchedOperation) scenario.Step {
+	path := matched.Path
+	for name, value := range test.PathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", generator.ParameterString(value))
+	}
+
+	headers := make(map[string]string, len(suite.Headers)+len(test.Headers))
+	for key, value := range suite.Headers {
+		headers[key] = value
+	}
+	for key, value := range test.Headers {
+		headers[key] = value
+	}
+
+	return scenario.Step{
+		ID: test.ID,
+		Request: scenario.Request{
+			Method:      strings.ToUpper(matched.Method),
+			URL:         path,
+			Headers:     headers,
+			Cookies:     test.Cookies,
+			QueryParams: test.QueryParams,
+			Body:        test.Body,
+			ContentType: test.ContentType,
+			TimeoutMs:   test.TimeoutMs,
+		},
+		Extract:      test.Extract,
+		ExpectStatus: test.ExpectStatus,
+		Expect:       test.Expect,
+		Assert:       test.Assert,
+	}
+}
+
+// schemaCheck validate response of step against operation
+func schemaCheck(matched *openapi.MatchedOperation, stepResult scenario.StepResult) scenario.Check {
+	response := stepResult.Response
+	validation := validator.ValidateResponse(matched.Operation, response.StatusCode, response.Headers["Content-Type"], response.RawBody)
+
+	check := scenario.Check{
+		Name:   "response matches schema",
+		Passed: validation.Valid,
+	}
+
+	if !validation.Valid {
+		messages := make([]string, 0, len(validation.Violations))
+		for _, violation := range validation.Violations {
+			messages = append(messages, violation.String())
+		}
+		check.Message = strings.Join(messages, "; ")
+	}
+
+	return check
+}
+
+func allPassed(checks []scenario.Check) bool {
+	for _, check := range checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
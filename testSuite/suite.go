@@ -0,0 +1,95 @@
+// Package testsuite define YAML or JSON file of regression tests that refer to operations of OpenAPI file,
+// suite can be run headless by `mcp-api-tester run -suite suite.yaml` in CI
+package testsuite
+
+import (
+	"fmt"
+	"mcp-api-tester/assertion"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is the content of test-suite file
+type Suite struct {
+	Name          string            `json:"name,omitempty" yaml:"name,omitempty"`
+	Spec          string            `json:"spec,omitempty" yaml:"spec,omitempty"`       // path or url of OpenAPI file, relative path is based on suite file
+	BaseURL       string            `json:"baseURL,omitempty" yaml:"baseURL,omitempty"` // the first server of spec will be used if empty
+	Variables     map[string]any    `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"` // send with every test
+	StopOnFailure bool              `json:"stopOnFailure,omitempty" yaml:"stopOnFailure,omitempty"`
+	Tests         []Test            `json:"tests" yaml:"tests"`
+}
+
+// Test is one request against an operation and what its response should be,
+// operation can be referred by operation like "GET /users/{id}", operationId or path with method
+type Test struct {
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"` // used by later tests like {{steps.create.body.id}}
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Operation   string `json:"operation,omitempty" yaml:"operation,omitempty"`
+	OperationID string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+	Method      string `json:"method,omitempty" yaml:"method,omitempty"`
+
+	PathParams  map[string]any    `json:"pathParams,omitempty" yaml:"pathParams,omitempty"`
+	QueryParams map[string]string `json:"queryParams,omitempty" yaml:"queryParams,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Cookies     map[string]string `json:"cookies,omitempty" yaml:"cookies,omitempty"`
+	Body        any               `json:"body,omitempty" yaml:"body,omitempty"`
+	ContentType string            `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	TimeoutMs   int               `json:"timeoutMs,omitempty" yaml:"timeoutMs,omitempty"`
+
+	ExpectStatus   []int                 `json:"expectStatus,omitempty" yaml:"expectStatus,omitempty"`
+	ValidateSchema bool                  `json:"validateSchema,omitempty" yaml:"validateSchema,omitempty"` // validate response against OpenAPI file
+	Expect         map[string]any        `json:"expect,omitempty" yaml:"expect,omitempty"`
+	Assert         []assertion.Assertion `json:"assert,omitempty" yaml:"assert,omitempty"` // like status range, header regex, JSONPath type or response time
+	Extract        map[string]string     `json:"extract,omitempty" yaml:"extract,omitempty"`
+}
+
+// Load read suite from YAML or JSON file, relative spec path will be resolved against directory of the file
+func Load(path string) (*Suite, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when read test suite %q, error: %w", path, err)
+	}
+
+	suite, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when parse test suite %q, error: %w", path, err)
+	}
+
+	if suite.Spec != "" && !filepath.IsAbs(suite.Spec) && !strings.Contains(suite.Spec, "://") {
+		suite.Spec = filepath.Join(filepath.Dir(path), suite.Spec)
+	}
+
+	return suite, nil
+}
+
+// Parse decode suite from YAML or JSON content
+func Parse(content []byte) (*Suite, error) {
+	var suite Suite
+
+	// JSON is valid YAML so one decoder is enough
+	if err := yaml.Unmarshal(content, &suite); err != nil {
+		return nil, err
+	}
+
+	if len(suite.Tests) == 0 {
+		return nil, fmt.Errorf("Test suite has no test")
+	}
+
+	return &suite, nil
+}
+
+// operationRef return how the test refer to operation for display like GET /users/{id}
+func (t Test) operationRef() string {
+	switch {
+	case t.Operation != "":
+		return t.Operation
+	case t.OperationID != "":
+		return t.OperationID
+	}
+	return strings.ToUpper(t.Method) + " " + t.Path
+}
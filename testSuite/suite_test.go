@@ -0,0 +1,163 @@
+package testsuite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const suiteSpec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      tags: [users]
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+  /users/{id}:
+    get:
+      operationId: getUser
+      tags: [users]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+
+const suiteFile = `name: users
+spec: openapi.yaml
+headers:
+  X-Token: secret
+tests:
+  - id: create
+    operationId: createUser
+    body:
+      name: Alice
+    expectStatus: [201]
+    validateSchema: true
+    extract:
+      userId: $.id
+  - name: get created user
+    operation: GET /users/{id}
+    pathParams:
+      id: "{{userId}}"
+    validateSchema: true
+    expect:
+      $.name: Alice
+  - name: broken user
+    path: /users/{id}
+    method: get
+    pathParams:
+      id: 7
+    validateSchema: true
+`
+
+func Test_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/users":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "Alice"})
+		case "/users/1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "Alice"})
+		default:
+			// name is missing so schema validation should fail
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "7"})
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(suiteSpec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "suite.yaml"), []byte(suiteFile), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	suite, err := Load(filepath.Join(dir, "suite.yaml"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	result, err := Run(t.Context(), suite, Options{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if result.Passed || result.Summary != (Summary{Total: 3, Passed: 2, Failed: 1}) {
+		t.Fatalf("Only broken user should fail, got %+v", result.Summary)
+	}
+
+	if result.Tests[0].Name != "createUser" || result.Tests[0].Operation != "POST /users" || result.Tests[0].Tags[0] != "users" {
+		t.Errorf("Name, operation and tags should be filled from spec, got %+v", result.Tests[0])
+	}
+
+	if result.Tests[1].Request.URL != server.URL+"/users/1" {
+		t.Errorf("Path param should use extracted variable, got %q", result.Tests[1].Request.URL)
+	}
+
+	broken := result.Tests[2]
+	last := broken.Checks[len(broken.Checks)-1]
+	if broken.Passed || last.Passed || !strings.Contains(last.Message, "name") {
+		t.Errorf("Schema check should fail on missing name, got %+v", broken.Checks)
+	}
+}
+
+func Test_Parse(t *testing.T) {
+	suite, err := Parse([]byte(`{"tests": [{"operation": "GET /health", "expectStatus": [200]}]}`))
+	if err != nil {
+		t.Fatalf("JSON suite should be parsed, got %v", err)
+	}
+
+	if _, err := Run(t.Context(), suite, Options{BaseURL: "http://127.0.0.1:0"}); err != nil {
+		t.Errorf("Suite without spec should be runnable, got %v", err)
+	}
+
+	if _, err := Parse([]byte(`name: empty`)); err == nil {
+		t.Errorf("Suite without test should be invalid")
+	}
+
+	suite.Tests[0].ValidateSchema = true
+	if _, err := Run(t.Context(), suite, Options{}); err == nil {
+		t.Errorf("Schema validation without spec should be invalid")
+	}
+}
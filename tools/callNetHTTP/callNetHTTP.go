@@ -0,0 +1,163 @@
+// Package callnethttp let LLM send real http request
+// and return status, headers and body of the response
+package callnethttp
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/assertion"
+	"mcp-api-tester/auth"
+	"mcp-api-tester/coverage"
+	netclient "mcp-api-tester/net/client"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+	"mcp-api-tester/validator"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultTimeoutMs will be used when timeoutMs is not provided
+const DefaultTimeoutMs = 30000
+
+// MaxRetries limit maxRetries so one call can't wait too long
+const MaxRetries = 5
+
+// Param provide param for callNetHTTP
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Method      string            `json:"method" jsonschema:"required,description=Http method of the request like GET or POST"`
+	URL         string            `json:"url" jsonschema:"required,description=Absolute url like https://example.com/users/1 or path like /users/{id} resolved against active environment or server of the operation in OpenAPI file"`
+	Headers     map[string]string `json:"headers,omitempty" jsonschema:"description=Request headers like Authorization or X-API-Key"`
+	Cookies     map[string]string `json:"cookies,omitempty" jsonschema:"description=Cookies that will be send with request like session cookie"`
+	QueryParams map[string]string `json:"queryParams,omitempty" jsonschema:"description=Query params that will be append to url"`
+	Body        any               `json:"body,omitempty" jsonschema:"description=Request body can be plain string or json object"`
+	ContentType string            `json:"contentType,omitempty" jsonschema:"description=Content-Type of request body like application/json"`
+	TimeoutMs   int               `json:"timeoutMs,omitempty" jsonschema:"description=Timeout of the request in milliseconds default is 30000"`
+	MaxRetries  int               `json:"maxRetries,omitempty" jsonschema:"description=Retries with exponential backoff when idempotent request fail to connect or get 429 or 503\\, POST and PATCH without Idempotency-Key header are only retried when response has Retry-After\\, default is 0 and can't exceed 5"`
+
+	PathParams      map[string]string `json:"pathParams,omitempty" jsonschema:"description=Values of path params like {id} in relative url\\, variables of active environment will be used if not provided"`
+	ServerVariables map[string]string `json:"serverVariables,omitempty" jsonschema:"description=Values of server variables like {region} used when relative url is resolved against server of operation\\, default of variable will be used if not provided"`
+
+	ValidateRequest bool   `json:"validateRequest,omitempty" jsonschema:"description=Check params and body against OpenAPI document before send\\, invalid request will not be send"`
+	SpecPath        string `json:"specPath,omitempty" jsonschema:"description=Templated path in OpenAPI file like /users/{id} used by validateRequest\\, matched from url if empty"`
+	SpecName        string `json:"specName,omitempty" jsonschema:"description=Alias of the document used by validateRequest and relative url\\, the last read document will be used if empty"`
+
+	Assertions []assertion.Assertion `json:"assertions,omitempty" jsonschema:"description=Checks applied to response like {source: status\\, op: range\\, value: 2xx} or {source: jsonPath\\, path: $.id\\, op: type\\, value: integer}\\, result of each is returned"`
+}
+
+// Result is what callNetHTTP return to LLM
+type Result struct {
+	Request   Param                    `json:"request"`
+	Auth      *auth.Status             `json:"auth,omitempty"`      // security schemes required by operation and which are attached
+	Preflight *validator.RequestResult `json:"preflight,omitempty"` // request will not be send if preflight is not valid
+	Response  *netclient.AIResponse    `json:"response,omitempty"`
+
+	Assertions []assertion.Result `json:"assertions,omitempty"`
+	Passed     *bool              `json:"passed,omitempty"` // whether every assertion passed, nil if no assertion is given
+}
+
+// ToAIRequest convert Param into netclient.AIRequest
+func (p Param) ToAIRequest() (*netclient.AIRequest, error) {
+	body, contentType, err := netclient.EncodeBody(p.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.ContentType != "" {
+		contentType = p.ContentType
+	}
+
+	timeoutMs := p.TimeoutMs
+	if timeoutMs <= 0 {
+		timeoutMs = DefaultTimeoutMs
+	}
+
+	return &netclient.AIRequest{
+		Method:      strings.ToUpper(p.Method),
+		URL:         p.URL,
+		Headers:     p.Headers,
+		Cookies:     p.Cookies,
+		QueryParams: p.QueryParams,
+		Body:        body,
+		ContentType: contentType,
+		TimeoutMs:   time.Duration(timeoutMs) * time.Millisecond,
+		MaxRetries:  min(max(p.MaxRetries, 0), MaxRetries),
+	}, nil
+}
+
+func callNetHTTP(ctx context.Context, args Param) (*Result, error) {
+	if args.Method == "" || args.URL == "" {
+		return nil, fmt.Errorf("Both method and url are required")
+	}
+
+	if err := assertion.Validate(args.Assertions); err != nil {
+		return nil, err
+	}
+
+	aiRequest, err := args.ToAIRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := toolutils.ResolveRequest(ctx, aiRequest, toolutils.ResolveOptions{
+		SpecName:        args.SpecName,
+		PathParams:      args.PathParams,
+		ServerVariables: args.ServerVariables,
+	}); err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Request: args,
+		Auth:    toolutils.ApplySecurity(ctx, args.SpecName, aiRequest),
+	}
+
+	if args.ValidateRequest {
+		result.Preflight, err = preflight(ctx, args, aiRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		if !result.Preflight.Valid {
+			return result, nil
+		}
+	}
+
+	response, err := aiRequest.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when send %s request to %q, error: %w", aiRequest.Method, aiRequest.URL, err)
+	}
+
+	result.Response = response
+
+	coverage.Trackers.Get(toolutils.SessionID(ctx)).Record(coverage.Request{
+		Method:      aiRequest.Method,
+		URL:         aiRequest.URL,
+		QueryParams: aiRequest.QueryParams,
+		Headers:     aiRequest.Headers,
+		Cookies:     aiRequest.Cookies,
+		StatusCode:  response.StatusCode,
+	})
+
+	if len(args.Assertions) > 0 {
+		result.Assertions = assertion.Evaluate(args.Assertions, response, assertedOperation(ctx, args, aiRequest))
+		passed := assertion.Passed(result.Assertions)
+		result.Passed = &passed
+	}
+
+	return result, nil
+}
+
+// CallNetHTTPTool can register callNetHTTP to MCP Server
+var CallNetHTTPTool = toolutils.MustTool(
+	tools.CallNetHTTP,
+	fmt.Sprintf("%s will send real http request and return status, headers, decoded body, size and elapsed time, assertions like status range, header regex, JSONPath type or length, schema and response time are checked if given, use %q to find out how to call an api", tools.CallNetHTTP, tools.GetSingleAPIDetail),
+	callNetHTTP,
+)
+
+// AddCallNetHTTPTool can register callNetHTTP to MCP Server
+func AddCallNetHTTPTool(mcp *server.MCPServer) {
+	CallNetHTTPTool.Register(mcp)
+}
@@ -0,0 +1,342 @@
+package callnethttp
+
+import (
+	"context"
+	"io"
+	"mcp-api-tester/assertion"
+	"mcp-api-tester/environment"
+	openapi "mcp-api-tester/openAPI"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_callNetHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Method should be %q, not %q", http.MethodPost, r.Method)
+		}
+
+		if r.URL.Query().Get("page") != "1" {
+			t.Errorf("Query param page should be %q, not %q", "1", r.URL.Query().Get("page"))
+		}
+
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type should be %q, not %q", "application/json", r.Header.Get("Content-Type"))
+		}
+
+		if string(body) != `{"name":"NewUser"}` {
+			t.Errorf("Body should be json, not %s", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer srv.Close()
+
+	result, err := callNetHTTP(context.Background(), Param{
+		Method:      "post",
+		URL:         srv.URL + "/users",
+		QueryParams: map[string]string{"page": "1"},
+		Body:        map[string]any{"name": "NewUser"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if result.Response.StatusCode != http.StatusCreated {
+		t.Errorf("StatusCode should be %d, not %d", http.StatusCreated, result.Response.StatusCode)
+	}
+
+	body, ok := result.Response.Body.(map[string]any)
+	if !ok || body["id"] != "123" {
+		t.Errorf("Body should be decoded as json, not %v", result.Response.Body)
+	}
+}
+
+func Test_callNetHTTPAssertions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[1,2,3]}`))
+	}))
+	defer srv.Close()
+
+	result, err := callNetHTTP(context.Background(), Param{
+		Method: "GET",
+		URL:    srv.URL,
+		Assertions: []assertion.Assertion{
+			{Source: assertion.SourceStatus, Op: assertion.OpEquals, Value: 200},
+			{Source: assertion.SourceJSONPath, Path: "$.items", Op: assertion.OpLength, Value: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("callNetHTTP failed: %v", err)
+	}
+
+	if result.Passed == nil || *result.Passed || len(result.Assertions) != 2 {
+		t.Fatalf("Assertions should be evaluated and fail, got %+v", result.Assertions)
+	}
+	if !result.Assertions[0].Passed || result.Assertions[1].Message != "got length 3" {
+		t.Errorf("Unexpected assertion results %+v", result.Assertions)
+	}
+
+	_, err = callNetHTTP(context.Background(), Param{
+		Method:     "GET",
+		URL:        srv.URL,
+		Assertions: []assertion.Assertion{{Source: assertion.SourceBody, Op: assertion.OpBelow, Value: 1}},
+	})
+	if err == nil {
+		t.Errorf("Invalid assertion should be rejected before request is sent")
+	}
+}
+
+func Test_callNetHTTPPreflight(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+      responses:
+        "204":
+          description: updated
+`
+	specPath := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	openapi.Documents.Store("", openapi.DefaultAlias, document)
+	defer openapi.Documents.RemoveSession("")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Errorf("Invalid request should not be send to server")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	result, err := callNetHTTP(context.Background(), Param{
+		Method:          "PUT",
+		URL:             srv.URL + "/users/abc",
+		Body:            map[string]any{},
+		ValidateRequest: true,
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if result.Preflight == nil || result.Preflight.Valid || len(result.Preflight.Violations) != 2 {
+		t.Errorf("Preflight should report path param and body violations, got %+v", result.Preflight)
+	}
+
+	if result.Response != nil {
+		t.Errorf("Response should be empty when preflight failed")
+	}
+}
+
+func Test_callNetHTTPPreflightSpecPath(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users/me:
+    put:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [nickname]
+      responses:
+        "204":
+          description: updated
+  /users/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+      responses:
+        "204":
+          description: updated
+`
+	specPath := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	openapi.Documents.Store("", openapi.DefaultAlias, document)
+	defer openapi.Documents.RemoveSession("")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// /users/me is matched literally by default but caller ask for /users/{id}
+	result, err := callNetHTTP(context.Background(), Param{
+		Method:          "PUT",
+		URL:             srv.URL + "/users/me",
+		Body:            map[string]any{"name": "Alice"},
+		ValidateRequest: true,
+		SpecPath:        "/users/{id}",
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if result.Preflight == nil || !result.Preflight.Valid || result.Response == nil {
+		t.Errorf("Request should be validated against /users/{id} and sent, got %+v", result.Preflight)
+	}
+
+	if _, err := callNetHTTP(context.Background(), Param{
+		Method:          "PUT",
+		URL:             srv.URL + "/orders/1",
+		Body:            map[string]any{"name": "Alice"},
+		ValidateRequest: true,
+		SpecPath:        "/users/{id}",
+	}); err == nil {
+		t.Errorf("Url that doesn't match specPath should be error")
+	}
+}
+
+func Test_callNetHTTPEnvironment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/users/42" {
+			t.Errorf("Path should be resolved against environment, not %q", r.URL.Path)
+		}
+
+		if r.Header.Get("X-API-Key") != "secret" {
+			t.Errorf("Api key of environment should be sent, not %q", r.Header.Get("X-API-Key"))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	config, err := environment.Parse([]byte(`environments:
+  local:
+    baseURL: ` + srv.URL + `/v1
+    variables:
+      id: "42"
+    auth:
+      type: apiKey
+      name: X-API-Key
+      value: secret
+`))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	environment.Environments.Load("", config)
+	defer environment.Environments.RemoveSession("")
+
+	if _, err := environment.Environments.Use("", "local"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	result, err := callNetHTTP(context.Background(), Param{
+		Method: "GET",
+		URL:    "/users/{id}",
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if result.Response.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode should be %d, not %d", http.StatusNoContent, result.Response.StatusCode)
+	}
+}
+
+func Test_callNetHTTPOperationServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/files" {
+			t.Errorf("Server of operation with variable should be used, not %q", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	spec := `openapi: 3.0.3
+info:
+  title: Files
+  version: 1.0.0
+servers:
+  - url: https://unused.example.com
+paths:
+  /files:
+    post:
+      servers:
+        - url: ` + srv.URL + `/{version}
+          variables:
+            version:
+              default: v1
+      responses:
+        "201":
+          description: created
+`
+	specPath := filepath.Join(t.TempDir(), "files.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	openapi.Documents.Store("", openapi.DefaultAlias, document)
+	defer openapi.Documents.RemoveSession("")
+
+	result, err := callNetHTTP(context.Background(), Param{
+		Method:          "POST",
+		URL:             "/files",
+		ServerVariables: map[string]string{"version": "v2"},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if result.Response.StatusCode != http.StatusCreated {
+		t.Errorf("StatusCode should be %d, not %d", http.StatusCreated, result.Response.StatusCode)
+	}
+}
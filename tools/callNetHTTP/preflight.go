@@ -0,0 +1,96 @@
+package callnethttp
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/assertion"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	toolutils "mcp-api-tester/tools/toolUtils"
+	"mcp-api-tester/validator"
+	"net/url"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// preflight validate request against operation in OpenAPI document before it is send
+func preflight(ctx context.Context, args Param, aiRequest *netclient.AIRequest) (*validator.RequestResult, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL, err := url.Parse(aiRequest.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when parse url: %q, error: %w", aiRequest.URL, err)
+	}
+
+	matched, err := matchOperation(document, args.SpecPath, aiRequest.Method, parsedURL.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams := make(map[string]string)
+	for key := range parsedURL.Query() {
+		queryParams[key] = parsedURL.Query().Get(key)
+	}
+	for key, value := range aiRequest.QueryParams {
+		queryParams[key] = value
+	}
+
+	result := validator.ValidateRequest(matched.Operation, matched.Parameters, validator.Request{
+		PathParams:  matched.PathParams,
+		QueryParams: queryParams,
+		Headers:     aiRequest.Headers,
+		Cookies:     aiRequest.Cookies,
+		ContentType: aiRequest.ContentType,
+		Body:        []byte(aiRequest.Body),
+	})
+
+	return &result, nil
+}
+
+// assertedOperation find operation of request for matchesSchema assertion, nil if it can't be found
+func assertedOperation(ctx context.Context, args Param, aiRequest *netclient.AIRequest) *v3high.Operation {
+	if !assertion.NeedSchema(args.Assertions) {
+		return nil
+	}
+
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(aiRequest.URL)
+	if err != nil {
+		return nil
+	}
+
+	matched, err := matchOperation(document, args.SpecPath, aiRequest.Method, parsedURL.Path)
+	if err != nil {
+		return nil
+	}
+
+	return matched.Operation
+}
+
+// matchOperation use operation of specPath like /users/{id} if it is given, since literal path like /users/me
+// is preferred when matching automatically, the operation is found by request path otherwise
+func matchOperation(document *openapi.OpenAPI, specPath string, method string, requestPath string) (*openapi.MatchedOperation, error) {
+	if specPath == "" {
+		return document.FindOperation(method, requestPath)
+	}
+
+	matched, err := document.GetMatchedOperation(specPath, method)
+	if err != nil {
+		return nil, err
+	}
+
+	pathParams, ok := document.MatchRequestPath(specPath, requestPath)
+	if !ok {
+		return nil, fmt.Errorf("Url path %q doesn't match %q in OpenAPI file", requestPath, specPath)
+	}
+	matched.PathParams = pathParams
+
+	return matched, nil
+}
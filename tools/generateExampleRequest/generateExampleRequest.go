@@ -0,0 +1,61 @@
+// Package generateexamplerequest will generate a ready-to-send request
+// of certain api from its parameters and request body schema
+package generateexamplerequest
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/generator"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for generateExampleRequest
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	URLPath         string `json:"urlPath" jsonschema:"required,description=The url path in OpenAPI file like /users/{id}"`
+	Method          string `json:"method" jsonschema:"required,description=Http method of the api,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	BaseURL         string `json:"baseURL,omitempty" jsonschema:"description=Base url like https://example.com/v1\\, the first server of the operation in OpenAPI file will be used if empty"`
+	IncludeOptional bool   `json:"includeOptional,omitempty" jsonschema:"description=Also generate optional params and body properties"`
+	SpecName        string `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
+
+func generateExampleRequest(ctx context.Context, args Param) (*generator.ExampleRequest, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := document.GetMatchedOperation(args.URLPath, args.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := args.BaseURL
+	if baseURL == "" {
+		baseURL, err = document.OperationServerURL(matched.Path, matched.Method, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request := generator.NewExampleRequest(matched, baseURL, generator.Options{
+		IncludeOptional: args.IncludeOptional,
+	})
+
+	return &request, nil
+}
+
+// GenerateExampleRequestTool can register generateExampleRequest to MCP Server
+var GenerateExampleRequestTool = toolutils.MustTool(
+	tools.GenerateExampleRequest,
+	fmt.Sprintf("%s will generate a ready-to-send request of certain api with path params, required query params and body filled from examples or schema, the result can be passed to %q directly", tools.GenerateExampleRequest, tools.CallNetHTTP),
+	generateExampleRequest,
+)
+
+// AddGenerateExampleRequestTool can register generateExampleRequest to MCP Server
+func AddGenerateExampleRequestTool(mcp *server.MCPServer) {
+	GenerateExampleRequestTool.Register(mcp)
+}
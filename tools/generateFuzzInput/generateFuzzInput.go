@@ -0,0 +1,90 @@
+// Package generatefuzzinput will generate mutated requests of certain api
+// from its parameters and request body schema for edge case and security testing
+package generatefuzzinput
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/fuzzer"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for generateFuzzInput
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	URLPath    string   `json:"urlPath" jsonschema:"required,description=The url path in OpenAPI file like /users/{id}"`
+	Method     string   `json:"method" jsonschema:"required,description=Http method of the api,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	BaseURL    string   `json:"baseURL,omitempty" jsonschema:"description=Base url like https://example.com/v1\\, the first server of the operation in OpenAPI file will be used if empty"`
+	Categories []string `json:"categories,omitempty" jsonschema:"description=Mutations to apply: boundary\\, overlong\\, wrongType\\, missingRequired\\, injection\\, invalidEnum and unicode\\, all of them will be used if empty"`
+	MaxCases   int      `json:"maxCases,omitempty" jsonschema:"description=Max number of cases to generate\\, default is 50 and can't exceed 500"`
+	SpecName   string   `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
+
+// Result is the cases generated by fuzzer
+type Result struct {
+	Count int           `json:"count"`
+	Cases []fuzzer.Case `json:"cases"`
+}
+
+func generateFuzzInput(ctx context.Context, args Param) (*Result, error) {
+	categories := make([]fuzzer.Category, 0, len(args.Categories))
+	for _, name := range args.Categories {
+		category, err := parseCategory(name)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := document.GetMatchedOperation(args.URLPath, args.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := args.BaseURL
+	if baseURL == "" {
+		baseURL, err = document.OperationServerURL(matched.Path, matched.Method, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cases := fuzzer.Generate(matched, baseURL, fuzzer.Options{
+		Categories: categories,
+		MaxCases:   args.MaxCases,
+	})
+
+	return &Result{
+		Count: len(cases),
+		Cases: cases,
+	}, nil
+}
+
+func parseCategory(name string) (fuzzer.Category, error) {
+	for _, category := range fuzzer.AllCategories {
+		if string(category) == name {
+			return category, nil
+		}
+	}
+	return "", fmt.Errorf("Category %q is not supported, please use one of %v", name, fuzzer.AllCategories)
+}
+
+// GenerateFuzzInputTool can register generateFuzzInput to MCP Server
+var GenerateFuzzInputTool = toolutils.MustTool(
+	tools.GenerateFuzzInput,
+	fmt.Sprintf("%s will generate mutated requests of certain api like boundary numbers, overlong strings, wrong types, missing required fields, injection strings, invalid enums and unicode edge cases, every case is tagged with the mutation and the expected class of response (4xx or 2xx), the request of each case can be passed to %q directly", tools.GenerateFuzzInput, tools.CallNetHTTP),
+	generateFuzzInput,
+)
+
+// AddGenerateFuzzInputTool can register generateFuzzInput to MCP Server
+func AddGenerateFuzzInputTool(mcp *server.MCPServer) {
+	GenerateFuzzInputTool.Register(mcp)
+}
@@ -0,0 +1,118 @@
+// Package generatetestreport will render stored test history of a run
+// into JUnit XML, JSON or Markdown report
+package generatetestreport
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/report"
+	"mcp-api-tester/storage"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+	"os"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MaxRecords is the max number of stored results put into one report
+const MaxRecords = 5000
+
+// Param provide param for generateTestReport
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Format     string `json:"format" jsonschema:"required,description=Format of report,enum=junit,enum=json,enum=markdown"`
+	RunID      string `json:"runId,omitempty" jsonschema:"description=Only report results of this run"`
+	Since      string `json:"since,omitempty" jsonschema:"description=Only report results created after this time in RFC3339 like 2024-01-01T00:00:00Z"`
+	Until      string `json:"until,omitempty" jsonschema:"description=Only report results created before this time in RFC3339"`
+	Name       string `json:"name,omitempty" jsonschema:"description=Title of report\\, default is run id"`
+	OutputPath string `json:"outputPath,omitempty" jsonschema:"description=Also write report into this file"`
+	SpecName   string `json:"specName,omitempty" jsonschema:"description=Alias of the document used to group results by tag\\, results are grouped by path if no document is loaded"`
+}
+
+// Result is the rendered report
+type Result struct {
+	Format     report.Format  `json:"format"`
+	Summary    report.Summary `json:"summary"`
+	OutputPath string         `json:"outputPath,omitempty"`
+	Content    string         `json:"content"`
+}
+
+func generateTestReport(ctx context.Context, args Param) (*Result, error) {
+	format, err := report.ParseFormat(args.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := storage.Filter{
+		RunID: args.RunID,
+		Limit: MaxRecords,
+	}
+
+	if filter.Since, err = storage.ParseTime(args.Since); err != nil {
+		return nil, err
+	}
+	if filter.Until, err = storage.ParseTime(args.Until); err != nil {
+		return nil, err
+	}
+
+	records, err := storage.Results.Query(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("No test result found, please use %q or %q with runId first", tools.StoreTestResult, tools.RunScenario)
+	}
+
+	name := args.Name
+	if name == "" {
+		name = args.RunID
+	}
+
+	var tagsOf func(operation string) []string
+	if document, err := toolutils.GetDocument(ctx, args.SpecName); err == nil {
+		tagsOf = func(operation string) []string {
+			method, path, _ := strings.Cut(operation, " ")
+			matched, err := document.GetMatchedOperation(path, method)
+			if err != nil {
+				return nil
+			}
+			return matched.Operation.Tags
+		}
+	} else if args.SpecName != "" {
+		return nil, err
+	}
+
+	testReport := report.FromRecords(name, records, tagsOf)
+
+	content, err := report.Render(testReport, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.OutputPath != "" {
+		if err := os.WriteFile(args.OutputPath, content, 0o644); err != nil {
+			return nil, fmt.Errorf("Error happened when write report to %q, error: %w", args.OutputPath, err)
+		}
+	}
+
+	return &Result{
+		Format:     format,
+		Summary:    testReport.Summary,
+		OutputPath: args.OutputPath,
+		Content:    string(content),
+	}, nil
+}
+
+// GenerateTestReportTool can register generateTestReport to MCP Server
+var GenerateTestReportTool = toolutils.MustTool(
+	tools.GenerateTestReport,
+	fmt.Sprintf("%s will render results saved by %q or %q into JUnit XML for CI, JSON, or Markdown summary grouped by tag or path with failure diffs", tools.GenerateTestReport, tools.StoreTestResult, tools.RunScenario),
+	generateTestReport,
+)
+
+// AddGenerateTestReportTool can register generateTestReport to MCP Server
+func AddGenerateTestReportTool(mcp *server.MCPServer) {
+	GenerateTestReportTool.Register(mcp)
+}
@@ -0,0 +1,56 @@
+// Package getauthtoken will request oauth2 token of a security scheme by credential set for this session,
+// the token is cached and shared with requests sent by other tools
+package getauthtoken
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/auth"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for getAuthToken
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Scheme   string   `json:"scheme" jsonschema:"required,description=Name of oauth2 security scheme in components.securitySchemes"`
+	Scopes   []string `json:"scopes,omitempty" jsonschema:"description=Scopes of token\\, scopes of credential will be used if empty"`
+	SpecName string   `json:"specName,omitempty" jsonschema:"description=Alias of the document that define the scheme\\, the last read document will be used if empty"`
+}
+
+func getAuthToken(ctx context.Context, args Param) (*auth.Token, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, ok := document.SecurityScheme(args.Scheme)
+	if !ok {
+		return nil, fmt.Errorf("Security scheme %q is not defined in OpenAPI file", args.Scheme)
+	}
+
+	if scheme.Type != "oauth2" {
+		return nil, fmt.Errorf("Only oauth2 scheme can request token, %q is %s", args.Scheme, scheme.Type)
+	}
+
+	credential, ok := toolutils.CredentialLookup(ctx)(scheme)
+	if !ok {
+		return nil, fmt.Errorf("No credential of %q is configured, use %q first", args.Scheme, tools.SetAuthCredential)
+	}
+
+	return auth.Tokens.Token(ctx, scheme, credential, args.Scopes)
+}
+
+// GetAuthTokenTool can register getAuthToken to MCP Server
+var GetAuthTokenTool = toolutils.MustTool(
+	tools.GetAuthToken,
+	fmt.Sprintf("%s will request oauth2 token from tokenUrl of the scheme by client credentials or password flow configured by %q, token is cached until it expire and %q attach it automatically, access token in result is redacted unless server is started with -no-redact", tools.GetAuthToken, tools.SetAuthCredential, tools.CallNetHTTP),
+	getAuthToken,
+)
+
+// AddGetAuthTokenTool can register getAuthToken to MCP Server
+func AddGetAuthTokenTool(mcp *server.MCPServer) {
+	GetAuthTokenTool.Register(mcp)
+}
@@ -0,0 +1,48 @@
+// Package getcoverage will report which operations of OpenAPI document have been exercised
+// by requests sent from this session
+package getcoverage
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/coverage"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for getCoverage
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	SpecName string `json:"specName,omitempty" jsonschema:"description=Alias of the document to compare with\\, the last read document will be used if empty"`
+	Reset    bool   `json:"reset,omitempty" jsonschema:"description=Forget recorded requests after report is returned"`
+}
+
+func getCoverage(ctx context.Context, args Param) (*coverage.Report, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker := coverage.Trackers.Get(toolutils.SessionID(ctx))
+	report := tracker.Report(document)
+
+	if args.Reset {
+		tracker.Reset()
+	}
+
+	return report, nil
+}
+
+// GetCoverageTool can register getCoverage to MCP Server
+var GetCoverageTool = toolutils.MustTool(
+	tools.GetCoverage,
+	fmt.Sprintf("%s will compare requests sent by %q and %q with OpenAPI document and report covered operations, undocumented or unobserved status codes and unused params", tools.GetCoverage, tools.CallNetHTTP, tools.RunScenario),
+	getCoverage,
+)
+
+// AddGetCoverageTool can register getCoverage to MCP Server
+func AddGetCoverageTool(mcp *server.MCPServer) {
+	GetCoverageTool.Register(mcp)
+}
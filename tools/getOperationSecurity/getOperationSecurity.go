@@ -0,0 +1,75 @@
+// Package getoperationsecurity will report security schemes of OpenAPI document
+// and which of them an operation require
+package getoperationsecurity
+
+import (
+	"context"
+	"fmt"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for getOperationSecurity
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	URLPath  string `json:"urlPath,omitempty" jsonschema:"description=The url path in OpenAPI file like /users/{id}\\, only schemes will be listed if empty"`
+	Method   string `json:"method,omitempty" jsonschema:"description=Http method of the api\\, required when urlPath is given,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	SpecName string `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
+
+// Scheme is security scheme and whether it has credential
+type Scheme struct {
+	openapi.SecurityScheme
+	Configured bool `json:"configured"`
+}
+
+// Result list schemes and requirements of operation
+type Result struct {
+	Schemes      []Scheme                      `json:"schemes"`
+	Requirements []openapi.SecurityRequirement `json:"requirements,omitempty"` // any one of them is enough, requirement without scheme means anonymous is allowed
+}
+
+func getOperationSecurity(ctx context.Context, args Param) (*Result, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := toolutils.CredentialLookup(ctx)
+
+	result := &Result{
+		Schemes: []Scheme{},
+	}
+
+	for _, scheme := range document.SecuritySchemes() {
+		_, configured := lookup(scheme)
+		result.Schemes = append(result.Schemes, Scheme{
+			SecurityScheme: scheme,
+			Configured:     configured,
+		})
+	}
+
+	if args.URLPath != "" {
+		result.Requirements, err = document.OperationSecurity(args.URLPath, args.Method)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// GetOperationSecurityTool can register getOperationSecurity to MCP Server
+var GetOperationSecurityTool = toolutils.MustTool(
+	tools.GetOperationSecurity,
+	fmt.Sprintf("%s will list security schemes of OpenAPI file with whether credential is configured by %q, and which schemes an operation require", tools.GetOperationSecurity, tools.SetAuthCredential),
+	getOperationSecurity,
+)
+
+// AddGetOperationSecurityTool can register getOperationSecurity to MCP Server
+func AddGetOperationSecurityTool(mcp *server.MCPServer) {
+	GetOperationSecurityTool.Register(mcp)
+}
@@ -4,7 +4,6 @@ package getsingleapidetail
 import (
 	"context"
 	"fmt"
-	openapi "mcp-api-tester/openAPI"
 	"mcp-api-tester/tools"
 	toolutils "mcp-api-tester/tools/toolUtils"
 
@@ -16,16 +15,18 @@ import (
 // Param provide param for readOpenAPIDocument
 // it will also be parse into tools description and mount to mcp server
 type Param struct {
-	URLPath string `json:"urlPath" jsonschema:"required,description=The url path is  the route url that you want to search"`
-	Method  string `json:"method" jsonschema:"required,description=Http method that you want to check of certain url path,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	URLPath  string `json:"urlPath" jsonschema:"required,description=The url path is  the route url that you want to search"`
+	Method   string `json:"method" jsonschema:"required,description=Http method that you want to check of certain url path,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	SpecName string `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
 }
 
-func getSingleAPIDetail(_ context.Context, args Param) (*v3high.Operation, error) {
-	if openapi.OpenAPIPointer == nil {
-		return nil, fmt.Errorf("OpenAPI file hasn't read, please use %q tool to read file first ", tools.ReadOpenAPIDocument)
+func getSingleAPIDetail(ctx context.Context, args Param) (*v3high.Operation, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
 	}
 
-	operation, err := openapi.OpenAPIPointer.GetOneAPIByPath(args.URLPath, args.Method)
+	operation, err := document.GetOneAPIByPath(args.URLPath, args.Method)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,115 @@
+// Package gettesthistory will query test results saved by storeTestResult
+package gettesthistory
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/storage"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultLimit will be used when limit is not provided
+const DefaultLimit = 50
+
+// Param provide param for getTestHistory
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	URL        string `json:"url,omitempty" jsonschema:"description=Url or path of endpoint like /users/{id} or https://example.com/users/1\\, all endpoints if empty"`
+	Method     string `json:"method,omitempty" jsonschema:"description=Http method of endpoint\\, all methods if empty"`
+	RunID      string `json:"runId,omitempty" jsonschema:"description=Only return results of this run"`
+	Since      string `json:"since,omitempty" jsonschema:"description=Only return results created after this time in RFC3339 like 2024-01-01T00:00:00Z"`
+	Until      string `json:"until,omitempty" jsonschema:"description=Only return results created before this time in RFC3339"`
+	Status     string `json:"status,omitempty" jsonschema:"description=Only return passed or failed results,enum=passed,enum=failed"`
+	StatusCode string `json:"statusCode,omitempty" jsonschema:"description=Only return results with status code like 404 or class like 5xx"`
+	Limit      int    `json:"limit,omitempty" jsonschema:"description=Max number of results newest first\\, default is 50"`
+	SpecName   string `json:"specName,omitempty" jsonschema:"description=Alias of the document used to match endpoint\\, results of other documents will be excluded"`
+}
+
+// Result is the history of endpoint
+type Result struct {
+	Count   int              `json:"count"`
+	Passed  int              `json:"passed"`
+	Failed  int              `json:"failed"`
+	Records []storage.Record `json:"records"`
+}
+
+func getTestHistory(ctx context.Context, args Param) (*Result, error) {
+	filter := storage.Filter{
+		RunID:      args.RunID,
+		StatusCode: args.StatusCode,
+		Limit:      args.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultLimit
+	}
+
+	if args.URL != "" {
+		spec, operation, err := toolutils.OperationOf(ctx, args.SpecName, args.Method, args.URL)
+		if err != nil {
+			return nil, err
+		}
+		filter.Operation = operation
+		if args.SpecName != "" {
+			filter.Spec = spec
+		}
+	} else if args.SpecName != "" {
+		document, err := toolutils.GetDocument(ctx, args.SpecName)
+		if err != nil {
+			return nil, err
+		}
+		filter.Spec = document.Info().Source
+	}
+
+	var err error
+	if filter.Since, err = storage.ParseTime(args.Since); err != nil {
+		return nil, err
+	}
+	if filter.Until, err = storage.ParseTime(args.Until); err != nil {
+		return nil, err
+	}
+
+	switch args.Status {
+	case "":
+	case "passed", "failed":
+		passed := args.Status == "passed"
+		filter.Passed = &passed
+	default:
+		return nil, fmt.Errorf("Status should be passed or failed, not %q", args.Status)
+	}
+
+	records, err := storage.Results.Query(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Count:   len(records),
+		Records: records,
+	}
+
+	for _, record := range records {
+		if record.Passed {
+			result.Passed++
+		} else {
+			result.Failed++
+		}
+	}
+
+	return result, nil
+}
+
+// GetTestHistoryTool can register getTestHistory to MCP Server
+var GetTestHistoryTool = toolutils.MustTool(
+	tools.GetTestHistory,
+	fmt.Sprintf("%s will return results saved by %q newest first, filtered by endpoint, run id, time range, pass or fail and status code, it helps to find regressions over time", tools.GetTestHistory, tools.StoreTestResult),
+	getTestHistory,
+)
+
+// AddGetTestHistoryTool can register getTestHistory to MCP Server
+func AddGetTestHistoryTool(mcp *server.MCPServer) {
+	GetTestHistoryTool.Register(mcp)
+}
@@ -0,0 +1,83 @@
+// Package importpostmancollection will import Postman collection and environment
+// so requests in it can be listed and run like scenario
+package importpostmancollection
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/postman"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for importPostmanCollection
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Path            string `json:"path" jsonschema:"required,description=Path of Postman collection v2.1 JSON file exported from Postman\\, try Absolute path if not work"`
+	EnvironmentPath string `json:"environmentPath,omitempty" jsonschema:"description=Path of Postman environment JSON file\\, its values override collection variables"`
+	Name            string `json:"name,omitempty" jsonschema:"description=Name that used to refer this collection in other tools\\, collection with same name will be replaced\\, default is name of the collection"`
+}
+
+// Result is summary of imported collection
+type Result struct {
+	Name        string                   `json:"name"`
+	Environment string                   `json:"environment,omitempty"`
+	Variables   []string                 `json:"variables"` // only names so secrets in environment are not return
+	Requests    []postman.RequestSummary `json:"requests"`
+	Warnings    []string                 `json:"warnings,omitempty"`
+}
+
+func importPostmanCollection(ctx context.Context, args Param) (*Result, error) {
+	collection, err := postman.LoadCollection(args.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var env *postman.Environment
+	if args.EnvironmentPath != "" {
+		if env, err = postman.LoadEnvironment(args.EnvironmentPath); err != nil {
+			return nil, err
+		}
+	}
+
+	imported := postman.Convert(collection, env)
+	if args.Name != "" {
+		imported.Name = args.Name
+	}
+	if imported.Name == "" {
+		imported.Name = strings.TrimSuffix(filepath.Base(args.Path), filepath.Ext(args.Path))
+	}
+
+	postman.Collections.Store(toolutils.SessionID(ctx), imported)
+
+	result := &Result{
+		Name:        imported.Name,
+		Environment: imported.Environment,
+		Variables:   make([]string, 0, len(imported.Variables)),
+		Requests:    postman.Summaries(imported.Requests),
+		Warnings:    imported.Warnings,
+	}
+	for name := range imported.Variables {
+		result.Variables = append(result.Variables, name)
+	}
+	slices.Sort(result.Variables)
+
+	return result, nil
+}
+
+// ImportPostmanCollectionTool can register importPostmanCollection to MCP Server
+var ImportPostmanCollectionTool = toolutils.MustTool(
+	tools.ImportPostmanCollection,
+	fmt.Sprintf("%s will import Postman collection and environment file, requests in folders are converted in order with variables, auth and simple pm.test checks like status, header, response time and JSON value, scripts that can't be converted are reported as warnings, requests can then be run by %q", tools.ImportPostmanCollection, tools.RunPostmanCollection),
+	importPostmanCollection,
+)
+
+// AddImportPostmanCollectionTool can register importPostmanCollection to MCP Server
+func AddImportPostmanCollectionTool(mcp *server.MCPServer) {
+	ImportPostmanCollectionTool.Register(mcp)
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	openapi "mcp-api-tester/openAPI"
 	"mcp-api-tester/tools"
 	toolutils "mcp-api-tester/tools/toolUtils"
 
@@ -15,14 +14,17 @@ import (
 
 // Param provide param for readOpenAPIDocument
 // it will also be parse into tools description and mount to mcp server
-type Param struct{}
+type Param struct {
+	SpecName string `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
 
-func listAllAPIFromDocument(_ context.Context, _ Param) (string, error) {
-	if openapi.OpenAPIPointer == nil {
-		return "", fmt.Errorf("OpenAPI file hasn't read, please use %q tool to read file first ", tools.ReadOpenAPIDocument)
+func listAllAPIFromDocument(ctx context.Context, args Param) (string, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return "", err
 	}
 
-	simplifyAPIs := openapi.OpenAPIPointer.ListAllAPIFromDocument()
+	simplifyAPIs := document.ListAllAPIFromDocument()
 
 	simplifyAPIBytes, err := json.Marshal(simplifyAPIs)
 
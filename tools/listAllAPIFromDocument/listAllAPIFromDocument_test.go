@@ -7,12 +7,14 @@ import (
 )
 
 func Test_ReadFromPath(t *testing.T) {
-	_, err := openapi.ReadFromPath("/home/tinymurky/Desktop/ISunFa.yaml")
+	document, err := openapi.ReadFromPath("/home/tinymurky/Desktop/ISunFa.yaml")
 
 	if err != nil {
-		t.Errorf("%v", err)
+		t.Fatalf("%v", err)
 	}
 
+	openapi.Documents.Store("", openapi.DefaultAlias, document)
+
 	param := Param{}
 	_, err = listAllAPIFromDocument(context.Background(), param)
 
@@ -0,0 +1,33 @@
+// Package listloadeddocuments will list every OpenAPI document
+// that loaded by current session with title, version and operation count
+package listloadeddocuments
+
+import (
+	"context"
+	"fmt"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for listLoadedDocuments
+// it will also be parse into tools description and mount to mcp server
+type Param struct{}
+
+func listLoadedDocuments(ctx context.Context, _ Param) ([]openapi.LoadedDocument, error) {
+	return openapi.Documents.List(toolutils.SessionID(ctx)), nil
+}
+
+// ListLoadedDocumentsTool can register listLoadedDocuments to MCP Server
+var ListLoadedDocumentsTool = toolutils.MustTool(
+	tools.ListLoadedDocuments,
+	fmt.Sprintf("%s will list all OpenAPI documents loaded by %q with alias, title, version and operation count, alias can be used as specName in other tools", tools.ListLoadedDocuments, tools.ReadOpenAPIDocument),
+	listLoadedDocuments,
+)
+
+// AddListLoadedDocumentsTool can register listLoadedDocuments to MCP Server
+func AddListLoadedDocumentsTool(mcp *server.MCPServer) {
+	ListLoadedDocumentsTool.Register(mcp)
+}
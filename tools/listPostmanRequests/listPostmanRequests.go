@@ -0,0 +1,56 @@
+// Package listpostmanrequests will list requests of imported Postman collection
+package listpostmanrequests
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/postman"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for listPostmanRequests
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Collection string `json:"collection,omitempty" jsonschema:"description=Name of imported collection\\, the last imported collection will be used if empty"`
+	Folder     string `json:"folder,omitempty" jsonschema:"description=Only list requests under folder like Users or Users/Admin"`
+}
+
+// Result is requests of collection and every imported collection of the session
+type Result struct {
+	Collection  string                       `json:"collection"`
+	Requests    []postman.RequestSummary     `json:"requests"`
+	Collections []postman.ImportedCollection `json:"collections"`
+}
+
+func listPostmanRequests(ctx context.Context, args Param) (*Result, error) {
+	imported, err := postman.Collections.Get(toolutils.SessionID(ctx), args.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := imported.Select(args.Folder, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Collection:  imported.Name,
+		Requests:    postman.Summaries(requests),
+		Collections: postman.Collections.List(toolutils.SessionID(ctx)),
+	}, nil
+}
+
+// ListPostmanRequestsTool can register listPostmanRequests to MCP Server
+var ListPostmanRequestsTool = toolutils.MustTool(
+	tools.ListPostmanRequests,
+	fmt.Sprintf("%s will list requests of Postman collection imported by %q with id, name, folder, method, url and conversion warnings, id can be used as requestIds in %q", tools.ListPostmanRequests, tools.ImportPostmanCollection, tools.RunPostmanCollection),
+	listPostmanRequests,
+)
+
+// AddListPostmanRequestsTool can register listPostmanRequests to MCP Server
+func AddListPostmanRequestsTool(mcp *server.MCPServer) {
+	ListPostmanRequestsTool.Register(mcp)
+}
@@ -0,0 +1,96 @@
+// Package listservers will list servers of OpenAPI document at document, path and operation level
+// with server variables replaced
+package listservers
+
+import (
+	"context"
+	"fmt"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for listServers
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	URLPath   string            `json:"urlPath,omitempty" jsonschema:"description=The url path in OpenAPI file like /users/{id}\\, only servers of document will be listed if empty"`
+	Method    string            `json:"method,omitempty" jsonschema:"description=Http method of the api\\, required when urlPath is given,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	Variables map[string]string `json:"variables,omitempty" jsonschema:"description=Values of server variables like region or version\\, default of variable will be used if not provided"`
+	SpecName  string            `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
+
+// ResolvedServer is server with its variables replaced
+type ResolvedServer struct {
+	openapi.Server
+	ResolvedURL string `json:"resolvedURL"`
+	Selected    bool   `json:"selected"` // the server that will be used when url is a relative path
+}
+
+// Result list servers from document level to operation level
+type Result struct {
+	Servers []ResolvedServer `json:"servers"`
+}
+
+func listServers(ctx context.Context, args Param) (*Result, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := document.DocumentServers()
+	selectedLevel := openapi.ServerLevelDocument
+
+	if args.URLPath != "" {
+		pathServers, operationServers, err := document.PathServers(args.URLPath, args.Method)
+		if err != nil {
+			return nil, err
+		}
+
+		servers = append(servers, pathServers...)
+		servers = append(servers, operationServers...)
+
+		switch {
+		case len(operationServers) > 0:
+			selectedLevel = openapi.ServerLevelOperation
+		case len(pathServers) > 0:
+			selectedLevel = openapi.ServerLevelPath
+		}
+	}
+
+	result := &Result{
+		Servers: make([]ResolvedServer, 0, len(servers)),
+	}
+
+	selected := false
+	for _, s := range servers {
+		resolvedURL, err := s.Resolve(args.Variables)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved := ResolvedServer{
+			Server:      s,
+			ResolvedURL: resolvedURL,
+			Selected:    !selected && s.Level == selectedLevel,
+		}
+		selected = selected || resolved.Selected
+
+		result.Servers = append(result.Servers, resolved)
+	}
+
+	return result, nil
+}
+
+// ListServersTool can register listServers to MCP Server
+var ListServersTool = toolutils.MustTool(
+	tools.ListServers,
+	fmt.Sprintf("%s will list servers of OpenAPI file at document, path and operation level with variables like {region} replaced, and mark the one %q use when url is a relative path", tools.ListServers, tools.CallNetHTTP),
+	listServers,
+)
+
+// AddListServersTool can register listServers to MCP Server
+func AddListServersTool(mcp *server.MCPServer) {
+	ListServersTool.Register(mcp)
+}
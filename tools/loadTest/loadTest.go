@@ -0,0 +1,127 @@
+// Package loadtest will send one request or scenario repeatedly
+// and report throughput, error rate, status distribution and latency percentiles
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/environment"
+	loadtester "mcp-api-tester/loadTest"
+	"mcp-api-tester/scenario"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for loadTest
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Request         *scenario.Request `json:"request,omitempty" jsonschema:"description=Request of the operation to load like params of CallNetHTTP\\, url can be path resolved against active environment or server of the operation\\, either request or steps is required"`
+	PathParams      map[string]string `json:"pathParams,omitempty" jsonschema:"description=Values of path params like {id} in relative url of request"`
+	ServerVariables map[string]string `json:"serverVariables,omitempty" jsonschema:"description=Values of server variables like {region} used when relative url is resolved"`
+	SpecName        string            `json:"specName,omitempty" jsonschema:"description=Alias of the document used by relative url and security\\, the last read document will be used if empty"`
+
+	Steps     []scenario.Step `json:"steps,omitempty" jsonschema:"description=Steps of scenario that is run as one iteration like RunScenario\\, failed scenario is counted as error"`
+	BaseURL   string          `json:"baseURL,omitempty" jsonschema:"description=Base url of steps\\, base url of active environment or the first server of OpenAPI file will be used if empty"`
+	Variables map[string]any  `json:"variables,omitempty" jsonschema:"description=Initial variables of scenario"`
+
+	Concurrency     int   `json:"concurrency,omitempty" jsonschema:"description=Workers sending in parallel\\, default is 1 and can't exceed 100"`
+	RatePerSecond   int   `json:"ratePerSecond,omitempty" jsonschema:"description=Total iterations per second of all workers\\, as fast as possible if empty and can't exceed 1000"`
+	DurationSeconds int   `json:"durationSeconds,omitempty" jsonschema:"description=How long to run\\, default is 10 seconds and can't exceed 300"`
+	Requests        int   `json:"requests,omitempty" jsonschema:"description=Stop after this many iterations even if duration is not over\\, can't exceed 100000"`
+	ExpectStatus    []int `json:"expectStatus,omitempty" jsonschema:"description=Status codes that are not errors\\, status below 400 is expected if empty"`
+}
+
+func loadTest(ctx context.Context, args Param) (*loadtester.Report, error) {
+	if (args.Request == nil) == (len(args.Steps) == 0) {
+		return nil, fmt.Errorf("Either request or steps is required")
+	}
+
+	options := loadtester.Options{
+		Concurrency:   args.Concurrency,
+		RatePerSecond: args.RatePerSecond,
+		Duration:      time.Duration(args.DurationSeconds) * time.Second,
+		Requests:      args.Requests,
+		ExpectStatus:  args.ExpectStatus,
+	}
+
+	if args.Request != nil {
+		aiRequest, err := args.Request.ToAIRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := toolutils.ResolveRequest(ctx, aiRequest, toolutils.ResolveOptions{
+			SpecName:        args.SpecName,
+			PathParams:      args.PathParams,
+			ServerVariables: args.ServerVariables,
+		}); err != nil {
+			return nil, err
+		}
+
+		// credentials and oauth2 token are resolved once instead of every iteration
+		status := toolutils.ApplySecurity(ctx, args.SpecName, aiRequest)
+
+		report := loadtester.Run(ctx, aiRequest.Method+" "+aiRequest.URL, loadtester.RequestTarget(*aiRequest), options)
+		if status != nil && status.Message != "" {
+			report.Warnings = append(report.Warnings, status.Message)
+		}
+		return report, nil
+	}
+
+	s, err := newScenario(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadtester.Run(ctx, fmt.Sprintf("scenario %q", s.Name), loadtester.ScenarioTarget(s), options), nil
+}
+
+// newScenario build scenario with base url and variables of active environment like runScenario
+func newScenario(ctx context.Context, args Param) (scenario.Scenario, error) {
+	profile := environment.Environments.Active(toolutils.SessionID(ctx))
+
+	s := scenario.Scenario{
+		Name:        "load test",
+		BaseURL:     args.BaseURL,
+		Variables:   map[string]any{},
+		Steps:       args.Steps,
+		Environment: profile,
+	}
+
+	if profile != nil {
+		if s.BaseURL == "" {
+			s.BaseURL = profile.BaseURL
+		}
+		for name, value := range profile.Variables {
+			s.Variables[name] = value
+		}
+	}
+
+	if document, err := toolutils.GetDocument(ctx, args.SpecName); err == nil {
+		s.Document = document
+		if s.BaseURL == "" {
+			s.BaseURL = document.DefaultServerURL()
+		}
+	}
+
+	for name, value := range args.Variables {
+		s.Variables[name] = value
+	}
+
+	return s, s.Validate()
+}
+
+// LoadTestTool can register loadTest to MCP Server
+var LoadTestTool = toolutils.MustTool(
+	tools.LoadTest,
+	fmt.Sprintf("%s will send one request or run scenario repeatedly at given concurrency, rate and duration and report throughput, error rate, status distribution, latency percentiles p50/p90/p99 and histogram, only use it on servers you are allowed to load, concurrency, rate, duration and requests are capped", tools.LoadTest),
+	loadTest,
+)
+
+// AddLoadTestTool can register loadTest to MCP Server
+func AddLoadTestTool(mcp *server.MCPServer) {
+	LoadTestTool.Register(mcp)
+}
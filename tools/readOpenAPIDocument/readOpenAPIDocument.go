@@ -7,6 +7,7 @@ import (
 	openapi "mcp-api-tester/openAPI"
 	"mcp-api-tester/tools"
 	toolutils "mcp-api-tester/tools/toolUtils"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -15,23 +16,38 @@ import (
 // Param provide param for readOpenAPIDocument
 // it will also be parse into tools description and mount to mcp server
 type Param struct {
-	OpenAPIPath string `json:"openAPIPath" jsonschema:"required,description=The path that lead to OpenAPI.yaml or OpenAPI.json or any OpenAPI file, try Absolute path if not work"`
+	OpenAPIPath string            `json:"openAPIPath" jsonschema:"required,description=The path or http(s) url that lead to OpenAPI.yaml or OpenAPI.json or any OpenAPI file\\, try Absolute path if not work"`
+	Headers     map[string]string `json:"headers,omitempty" jsonschema:"description=Headers like Authorization that will be send when openAPIPath is http(s) url"`
+	TimeoutMs   int               `json:"timeoutMs,omitempty" jsonschema:"description=Timeout in milliseconds when openAPIPath is http(s) url default is 30000"`
+	Alias       string            `json:"alias,omitempty" jsonschema:"description=Name that used to refer this document as specName in other tools\\, document with same alias will be replaced\\, default is 'default'"`
 }
 
-func readOpenAPIDocument(_ context.Context, args Param) (*mcp.CallToolResult, error) {
-	_, err := openapi.ReadFromPath(args.OpenAPIPath)
+func readOpenAPIDocument(ctx context.Context, args Param) (*mcp.CallToolResult, error) {
+	var document *openapi.OpenAPI
+	var err error
+
+	if openapi.IsRemotePath(args.OpenAPIPath) {
+		document, err = openapi.ReadFromURL(args.OpenAPIPath, openapi.RemoteOption{
+			Headers: args.Headers,
+			Timeout: time.Duration(args.TimeoutMs) * time.Millisecond,
+		})
+	} else {
+		document, err = openapi.ReadFromPath(args.OpenAPIPath)
+	}
 
 	if err != nil {
 		return mcp.NewToolResultText("error"), err
 	}
 
+	openapi.Documents.Store(toolutils.SessionID(ctx), args.Alias, document)
+
 	return mcp.NewToolResultText("success"), nil
 }
 
 // ReadOpenAPIDocumentTool can register readOpenAPIDocument to MCP Server
 var ReadOpenAPIDocumentTool = toolutils.MustTool(
 	tools.ReadOpenAPIDocument,
-	fmt.Sprintf("%s will read OpenAPI file by given Path, Please run this tool first to load OpenAPI file before using other tools", tools.ReadOpenAPIDocument),
+	fmt.Sprintf("%s will read OpenAPI file by given Path or http(s) url, Please run this tool first to load OpenAPI file before using other tools", tools.ReadOpenAPIDocument),
 	readOpenAPIDocument,
 )
 
@@ -0,0 +1,88 @@
+// Package runpostmancollection will run requests of imported Postman collection in order
+// like Postman collection runner and return trace of every request
+package runpostmancollection
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/environment"
+	"mcp-api-tester/postman"
+	"mcp-api-tester/scenario"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for runPostmanCollection
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Collection string         `json:"collection,omitempty" jsonschema:"description=Name of imported collection\\, the last imported collection will be used if empty"`
+	Folder     string         `json:"folder,omitempty" jsonschema:"description=Only run requests under folder like Users or Users/Admin"`
+	RequestIDs []string       `json:"requestIds,omitempty" jsonschema:"description=Only run requests of these ids in collection order\\, every request will be run if both folder and requestIds are empty"`
+	BaseURL    string         `json:"baseURL,omitempty" jsonschema:"description=Base url that url start with / will be appended to\\, base url of active environment or the first server of OpenAPI file will be used if empty"`
+	Variables  map[string]any `json:"variables,omitempty" jsonschema:"description=Variables like baseUrl or token that override collection and environment variables"`
+	RunID      string         `json:"runId,omitempty" jsonschema:"description=Save every executed request into test history under this run id so it can be queried or reported later"`
+}
+
+func runPostmanCollection(ctx context.Context, args Param) (*scenario.Result, error) {
+	imported, err := postman.Collections.Get(toolutils.SessionID(ctx), args.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := imported.Select(args.Folder, args.RequestIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	s := imported.Scenario(requests)
+	s.BaseURL = args.BaseURL
+
+	profile := environment.Environments.Active(toolutils.SessionID(ctx))
+	s.Environment = profile
+
+	if profile != nil {
+		if s.BaseURL == "" {
+			s.BaseURL = profile.BaseURL
+		}
+		for name, value := range profile.Variables {
+			s.Variables[name] = value
+		}
+	}
+
+	if document, err := toolutils.GetDocument(ctx, ""); err == nil {
+		s.Document = document
+		if s.BaseURL == "" {
+			s.BaseURL = document.DefaultServerURL()
+		}
+	}
+
+	for name, value := range args.Variables {
+		s.Variables[name] = value
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := scenario.Run(ctx, s)
+
+	if err := toolutils.RecordScenario(ctx, args.RunID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RunPostmanCollectionTool can register runPostmanCollection to MCP Server
+var RunPostmanCollectionTool = toolutils.MustTool(
+	tools.RunPostmanCollection,
+	fmt.Sprintf("%s will run requests of Postman collection imported by %q in order and keep going after failure like Postman runner, variables set by test scripts are passed to later requests, variables of active environment override collection variables, trace and checks of every request is return", tools.RunPostmanCollection, tools.ImportPostmanCollection),
+	runPostmanCollection,
+)
+
+// AddRunPostmanCollectionTool can register runPostmanCollection to MCP Server
+func AddRunPostmanCollectionTool(mcp *server.MCPServer) {
+	RunPostmanCollectionTool.Register(mcp)
+}
@@ -0,0 +1,82 @@
+// Package runscenario will run ordered http requests that pass values to each other
+// and return trace of every step
+package runscenario
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/environment"
+	"mcp-api-tester/scenario"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for runScenario
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Name              string          `json:"name,omitempty" jsonschema:"description=Name of the scenario like create then delete user"`
+	BaseURL           string          `json:"baseURL,omitempty" jsonschema:"description=Base url like https://example.com/v1 that url start with / will be appended to\\, base url of active environment or the first server of OpenAPI file will be used if empty"`
+	Variables         map[string]any  `json:"variables,omitempty" jsonschema:"description=Initial variables that can be used like {{vars.name}} or {{name}}\\, variables of active environment are also included"`
+	Steps             []scenario.Step `json:"steps" jsonschema:"required,description=Ordered steps\\, later steps can use response of earlier steps like {{steps.create.body.id}} or {{steps.create.headers.Location}}"`
+	ContinueOnFailure bool            `json:"continueOnFailure,omitempty" jsonschema:"description=Keep running remaining steps after a step failed\\, default is stop"`
+	RunID             string          `json:"runId,omitempty" jsonschema:"description=Save every executed step into test history under this run id so it can be queried or reported later"`
+}
+
+func runScenario(ctx context.Context, args Param) (*scenario.Result, error) {
+	profile := environment.Environments.Active(toolutils.SessionID(ctx))
+
+	s := scenario.Scenario{
+		Name:              args.Name,
+		BaseURL:           args.BaseURL,
+		Variables:         map[string]any{},
+		Steps:             args.Steps,
+		ContinueOnFailure: args.ContinueOnFailure,
+		Environment:       profile,
+	}
+
+	if profile != nil {
+		if s.BaseURL == "" {
+			s.BaseURL = profile.BaseURL
+		}
+		for name, value := range profile.Variables {
+			s.Variables[name] = value
+		}
+	}
+
+	if document, err := toolutils.GetDocument(ctx, ""); err == nil {
+		s.Document = document
+		if s.BaseURL == "" {
+			s.BaseURL = document.DefaultServerURL()
+		}
+	}
+
+	for name, value := range args.Variables {
+		s.Variables[name] = value
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := scenario.Run(ctx, s)
+
+	if err := toolutils.RecordScenario(ctx, args.RunID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RunScenarioTool can register runScenario to MCP Server
+var RunScenarioTool = toolutils.MustTool(
+	tools.RunScenario,
+	fmt.Sprintf("%s will send requests in order like create user, extract id, get user then delete it, values are extracted by JSONPath or header into variables and every step is checked by expected status, values and assertions, remaining steps are skipped after a failure, trace of every step is return", tools.RunScenario),
+	runScenario,
+)
+
+// AddRunScenarioTool can register runScenario to MCP Server
+func AddRunScenarioTool(mcp *server.MCPServer) {
+	RunScenarioTool.Register(mcp)
+}
@@ -0,0 +1,91 @@
+// Package setauthcredential will save credential of a security scheme for this session,
+// it will be attached to every request whose operation require that scheme
+package setauthcredential
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/auth"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for setAuthCredential
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Scheme   string `json:"scheme" jsonschema:"required,description=Name of security scheme in components.securitySchemes like bearerAuth"`
+	Token    string `json:"token,omitempty" jsonschema:"description=Token of http bearer\\, oauth2 or openIdConnect scheme"`
+	Username string `json:"username,omitempty" jsonschema:"description=Username of http basic scheme or oauth2 password flow"`
+	Password string `json:"password,omitempty" jsonschema:"description=Password of http basic scheme or oauth2 password flow"`
+	Value    string `json:"value,omitempty" jsonschema:"description=Key of apiKey scheme or whole Authorization header of other http schemes"`
+
+	ClientID         string   `json:"clientId,omitempty" jsonschema:"description=Client id of oauth2 scheme\\, token will be requested from tokenUrl and cached until it expire"`
+	ClientSecret     string   `json:"clientSecret,omitempty" jsonschema:"description=Client secret of oauth2 scheme"`
+	Scopes           []string `json:"scopes,omitempty" jsonschema:"description=Scopes of oauth2 token\\, scopes required by operation will be used if empty"`
+	Flow             string   `json:"flow,omitempty" jsonschema:"description=Oauth2 flow used to request token\\, password if username is given otherwise clientCredentials,enum=clientCredentials,enum=password"`
+	TokenURL         string   `json:"tokenUrl,omitempty" jsonschema:"description=Token url that override tokenUrl in OpenAPI file"`
+	ClientAuthInBody bool     `json:"clientAuthInBody,omitempty" jsonschema:"description=Send client id and secret in form body instead of basic auth"`
+
+	Remove   bool   `json:"remove,omitempty" jsonschema:"description=Remove credential of the scheme instead of saving it"`
+	SpecName string `json:"specName,omitempty" jsonschema:"description=Alias of the document that define the scheme\\, the last read document will be used if empty"`
+}
+
+// Result list schemes that have credential in this session
+type Result struct {
+	Configured []string `json:"configured"`
+}
+
+func setAuthCredential(ctx context.Context, args Param) (*Result, error) {
+	sessionID := toolutils.SessionID(ctx)
+
+	if args.Remove {
+		auth.Credentials.Delete(sessionID, args.Scheme)
+		return &Result{Configured: auth.Credentials.Schemes(sessionID)}, nil
+	}
+
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, ok := document.SecurityScheme(args.Scheme)
+	if !ok {
+		return nil, fmt.Errorf("Security scheme %q is not defined in OpenAPI file, use %q to list schemes", args.Scheme, tools.GetOperationSecurity)
+	}
+
+	credential := auth.Credential{
+		Token:    args.Token,
+		Username: args.Username,
+		Password: args.Password,
+		Value:    args.Value,
+
+		ClientID:         args.ClientID,
+		ClientSecret:     args.ClientSecret,
+		Scopes:           args.Scopes,
+		Flow:             args.Flow,
+		TokenURL:         args.TokenURL,
+		ClientAuthInBody: args.ClientAuthInBody,
+	}
+
+	if err := auth.Validate(scheme, credential); err != nil {
+		return nil, err
+	}
+
+	auth.Credentials.Set(sessionID, args.Scheme, credential)
+
+	return &Result{Configured: auth.Credentials.Schemes(sessionID)}, nil
+}
+
+// SetAuthCredentialTool can register setAuthCredential to MCP Server
+var SetAuthCredentialTool = toolutils.MustTool(
+	tools.SetAuthCredential,
+	fmt.Sprintf("%s will save credential of a security scheme in OpenAPI file, %q will attach it as header, query or cookie to every operation that require the scheme", tools.SetAuthCredential, tools.CallNetHTTP),
+	setAuthCredential,
+)
+
+// AddSetAuthCredentialTool can register setAuthCredential to MCP Server
+func AddSetAuthCredentialTool(mcp *server.MCPServer) {
+	SetAuthCredentialTool.Register(mcp)
+}
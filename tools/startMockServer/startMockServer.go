@@ -0,0 +1,91 @@
+// Package startmockserver will start a local mock server from loaded OpenAPI document
+package startmockserver
+
+import (
+	"context"
+	"fmt"
+	mockserver "mcp-api-tester/mockServer"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OverrideParam replace the response of one operation
+type OverrideParam struct {
+	URLPath     string            `json:"urlPath" jsonschema:"required,description=The url path in OpenAPI file like /users/{id}"`
+	Method      string            `json:"method" jsonschema:"required,description=Http method of the api,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	StatusCode  int               `json:"statusCode,omitempty" jsonschema:"description=Status code to response\\, default is 200"`
+	Headers     map[string]string `json:"headers,omitempty" jsonschema:"description=Headers to response"`
+	ContentType string            `json:"contentType,omitempty" jsonschema:"description=Content type of body\\, default is application/json"`
+	Body        any               `json:"body,omitempty" jsonschema:"description=Body to response\\, string will be sent as it is and others will be encoded as json"`
+}
+
+// Param provide param for startMockServer
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Port            int             `json:"port,omitempty" jsonschema:"description=Port to listen on localhost\\, a random free port will be used if empty"`
+	ValidateRequest bool            `json:"validateRequest,omitempty" jsonschema:"description=Response 400 with violations if request doesn't match the OpenAPI file"`
+	Overrides       []OverrideParam `json:"overrides,omitempty" jsonschema:"description=Fixed responses of certain operations instead of generated ones"`
+	SpecName        string          `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
+
+// Result tell where the mock server is listening
+type Result struct {
+	URL            string `json:"url"`
+	OperationCount int    `json:"operationCount"`
+}
+
+func startMockServer(ctx context.Context, args Param) (*Result, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	mockServer := mockserver.New(document, mockserver.Options{
+		ValidateRequest: args.ValidateRequest,
+	})
+
+	for _, override := range args.Overrides {
+		err := mockServer.SetOverride(override.URLPath, override.Method, mockserver.Override{
+			StatusCode:  override.StatusCode,
+			Headers:     override.Headers,
+			ContentType: override.ContentType,
+			Body:        override.Body,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Error happened when override response of %s %s, error: %w", override.Method, override.URLPath, err)
+		}
+	}
+
+	// previous server should release its port before the new one listen, restart usually use the same port
+	if _, err := mockserver.Servers.Stop(ctx, toolutils.SessionID(ctx)); err != nil {
+		return nil, fmt.Errorf("Error happened when close previous mock server, error: %w", err)
+	}
+
+	url, err := mockServer.Start(fmt.Sprintf("127.0.0.1:%d", args.Port))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := mockserver.Servers.Store(ctx, toolutils.SessionID(ctx), mockServer); err != nil {
+		return nil, fmt.Errorf("Error happened when close previous mock server, error: %w", err)
+	}
+
+	return &Result{
+		URL:            url,
+		OperationCount: document.Info().OperationCount,
+	}, nil
+}
+
+// StartMockServerTool can register startMockServer to MCP Server
+var StartMockServerTool = toolutils.MustTool(
+	tools.StartMockServer,
+	fmt.Sprintf("%s will start a local http server that response every path and method in OpenAPI file with example or schema-generated body and documented status code, header `Prefer: code=404` can choose another documented status, the previous mock server of this session will be closed, use %q to stop it", tools.StartMockServer, tools.StopMockServer),
+	startMockServer,
+)
+
+// AddStartMockServerTool can register startMockServer to MCP Server
+func AddStartMockServerTool(mcp *server.MCPServer) {
+	StartMockServerTool.Register(mcp)
+}
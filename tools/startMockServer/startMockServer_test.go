@@ -0,0 +1,63 @@
+package startmockserver
+
+import (
+	mockserver "mcp-api-tester/mockServer"
+	openapi "mcp-api-tester/openAPI"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_startMockServerTwiceOnSamePort(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: users
+          content:
+            application/json:
+              example: [{"id": 1}]
+`
+	specPath := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	openapi.Documents.Store("", openapi.DefaultAlias, document)
+	defer openapi.Documents.RemoveSession("")
+	defer func() { _, _ = mockserver.Servers.Stop(t.Context(), "") }()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	for i := range 2 {
+		result, err := startMockServer(t.Context(), Param{Port: port})
+		if err != nil {
+			t.Fatalf("start %d should close previous server first, got %v", i+1, err)
+		}
+
+		response, err := http.Get(result.URL + "/users")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("expected 200 from mock server, got %d", response.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package stopmockserver will stop the mock server started by this session
+package stopmockserver
+
+import (
+	"context"
+	"fmt"
+	mockserver "mcp-api-tester/mockServer"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for stopMockServer
+// it will also be parse into tools description and mount to mcp server
+type Param struct{}
+
+// Result tell whether a mock server was stopped
+type Result struct {
+	Stopped bool `json:"stopped"`
+}
+
+func stopMockServer(ctx context.Context, _ Param) (*Result, error) {
+	stopped, err := mockserver.Servers.Stop(ctx, toolutils.SessionID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("Error happened when stop mock server, error: %w", err)
+	}
+
+	return &Result{Stopped: stopped}, nil
+}
+
+// StopMockServerTool can register stopMockServer to MCP Server
+var StopMockServerTool = toolutils.MustTool(
+	tools.StopMockServer,
+	fmt.Sprintf("%s will stop the mock server started by %q", tools.StopMockServer, tools.StartMockServer),
+	stopMockServer,
+)
+
+// AddStopMockServerTool can register stopMockServer to MCP Server
+func AddStopMockServerTool(mcp *server.MCPServer) {
+	StopMockServerTool.Register(mcp)
+}
@@ -0,0 +1,91 @@
+// Package storetestresult will save executed request and its result
+// so they can be queried later by getTestHistory
+package storetestresult
+
+import (
+	"context"
+	"fmt"
+	netclient "mcp-api-tester/net/client"
+	"mcp-api-tester/storage"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// AssertionParam is the outcome of one check applied to response
+type AssertionParam struct {
+	Name    string `json:"name" jsonschema:"required,description=What was checked like status is 200 or body.id exists"`
+	Passed  bool   `json:"passed" jsonschema:"required,description=Whether the check passed"`
+	Message string `json:"message,omitempty" jsonschema:"description=Why the check failed"`
+}
+
+// Param provide param for storeTestResult
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Method       string            `json:"method" jsonschema:"required,description=Http method of the request like GET or POST"`
+	URL          string            `json:"url" jsonschema:"required,description=Url or path that was called like https://example.com/users/1\\, it will be matched to operation like /users/{id} of loaded OpenAPI file"`
+	Passed       bool              `json:"passed" jsonschema:"required,description=Whether the test passed"`
+	RunID        string            `json:"runId,omitempty" jsonschema:"description=Id that group results of the same test run"`
+	Request      any               `json:"request,omitempty" jsonschema:"description=Request that was sent like params of CallNetHTTP"`
+	StatusCode   int               `json:"statusCode,omitempty" jsonschema:"description=Status code of response\\, empty if no response received"`
+	ElapsedMs    int64             `json:"elapsedMs,omitempty" jsonschema:"description=Time spent on request in milliseconds"`
+	ResponseBody any               `json:"responseBody,omitempty" jsonschema:"description=Body of response or part of it worth keeping"`
+	Timing       *netclient.Timing `json:"timing,omitempty" jsonschema:"description=Timing of response returned by CallNetHTTP like dnsMs\\, connectMs\\, tlsMs\\, waitMs and transferMs"`
+	Assertions   []AssertionParam  `json:"assertions,omitempty" jsonschema:"description=Checks applied to response"`
+	ErrorMessage string            `json:"errorMessage,omitempty" jsonschema:"description=Why the test failed"`
+	SpecName     string            `json:"specName,omitempty" jsonschema:"description=Alias of the document used to match operation\\, the last read document will be used if empty"`
+}
+
+func storeTestResult(ctx context.Context, args Param) (*storage.Record, error) {
+	spec, operation, err := toolutils.OperationOf(ctx, args.SpecName, args.Method, args.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	record := storage.Record{
+		RunID:        args.RunID,
+		Spec:         spec,
+		Operation:    operation,
+		URL:          args.URL,
+		Request:      args.Request,
+		Passed:       args.Passed,
+		ErrorMessage: args.ErrorMessage,
+	}
+
+	if args.StatusCode != 0 {
+		record.Response = &storage.ResponseSummary{
+			StatusCode: args.StatusCode,
+			ElapsedMs:  args.ElapsedMs,
+			Body:       args.ResponseBody,
+			Timing:     args.Timing,
+		}
+	}
+
+	for _, assertion := range args.Assertions {
+		record.Assertions = append(record.Assertions, storage.AssertionResult{
+			Name:    assertion.Name,
+			Passed:  assertion.Passed,
+			Message: assertion.Message,
+		})
+	}
+
+	record, err = storage.Results.Append(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// StoreTestResultTool can register storeTestResult to MCP Server
+var StoreTestResultTool = toolutils.MustTool(
+	tools.StoreTestResult,
+	fmt.Sprintf("%s will save executed request, response summary, assertions and pass or fail into persistent storage, use %q to query them later", tools.StoreTestResult, tools.GetTestHistory),
+	storeTestResult,
+)
+
+// AddStoreTestResultTool can register storeTestResult to MCP Server
+func AddStoreTestResultTool(mcp *server.MCPServer) {
+	StoreTestResultTool.Register(mcp)
+}
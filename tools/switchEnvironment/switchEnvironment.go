@@ -0,0 +1,58 @@
+// Package switchenvironment will load environment file and switch the active environment of this session
+package switchenvironment
+
+import (
+	"context"
+	"fmt"
+	"mcp-api-tester/environment"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for switchEnvironment
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	Name       string `json:"name,omitempty" jsonschema:"description=Name of environment to use like dev or staging\\, only list environments if empty"`
+	ConfigPath string `json:"configPath,omitempty" jsonschema:"description=Path of YAML or JSON environment file that replace the one loaded at start\\, ${NAME} in values will be read from environment variable"`
+}
+
+// Result list every environment and which one is active, credentials are not included
+type Result struct {
+	Environments []environment.Summary `json:"environments"`
+}
+
+func switchEnvironment(ctx context.Context, args Param) (*Result, error) {
+	sessionID := toolutils.SessionID(ctx)
+
+	if args.ConfigPath != "" {
+		config, err := environment.Load(args.ConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		environment.Environments.Load(sessionID, config)
+	}
+
+	if args.Name != "" {
+		if _, err := environment.Environments.Use(sessionID, args.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Result{
+		Environments: environment.Environments.List(sessionID),
+	}, nil
+}
+
+// SwitchEnvironmentTool can register switchEnvironment to MCP Server
+var SwitchEnvironmentTool = toolutils.MustTool(
+	tools.SwitchEnvironment,
+	fmt.Sprintf("%s will load environment file and switch active environment like dev or staging, base url, default headers, variables and credentials of active environment will be used by %q and %q so url can be a path like /users/{id}", tools.SwitchEnvironment, tools.CallNetHTTP, tools.RunScenario),
+	switchEnvironment,
+)
+
+// AddSwitchEnvironmentTool can register switchEnvironment to MCP Server
+func AddSwitchEnvironmentTool(mcp *server.MCPServer) {
+	SwitchEnvironmentTool.Register(mcp)
+}
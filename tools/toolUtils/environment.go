@@ -0,0 +1,76 @@
+package toolutils
+
+import (
+	"context"
+	"mcp-api-tester/environment"
+	netclient "mcp-api-tester/net/client"
+	"strings"
+)
+
+// ResolveOptions provide values used when resolving relative url
+type ResolveOptions struct {
+	SpecName        string            // alias of document whose servers are used
+	PathParams      map[string]string // values of path params like {id}
+	ServerVariables map[string]string // values of server variables like {region}
+}
+
+// ResolveRequest resolve relative url like /users/{id} against active environment of the session
+// or the server that apply to the matched operation of document, then add default headers, variables and credentials of active environment,
+// error only happen when url can't be resolved or specName is given but not loaded
+func ResolveRequest(ctx context.Context, request *netclient.AIRequest, options ResolveOptions) error {
+	profile := environment.Environments.Active(SessionID(ctx))
+
+	fallbackBaseURL := ""
+	if strings.HasPrefix(request.URL, "/") {
+		var err error
+		if fallbackBaseURL, err = operationServerURL(ctx, profile, request, options); err != nil {
+			return err
+		}
+	}
+
+	resolvedURL, err := profile.ResolveURL(request.URL, options.PathParams, fallbackBaseURL)
+	if err != nil {
+		return err
+	}
+
+	request.URL = resolvedURL
+	profile.Apply(request)
+
+	return nil
+}
+
+// operationServerURL return url of server of operation that relative url match,
+// the first server of document will be used if nothing matched,
+// server variables can also come from variables of environment
+func operationServerURL(ctx context.Context, profile *environment.Profile, request *netclient.AIRequest, options ResolveOptions) (string, error) {
+	document, err := GetDocument(ctx, options.SpecName)
+	if err != nil {
+		if options.SpecName != "" {
+			return "", err
+		}
+		return "", nil
+	}
+
+	values := make(map[string]string)
+	if profile != nil {
+		for name, value := range profile.Variables {
+			values[name] = value
+		}
+	}
+	for name, value := range options.ServerVariables {
+		values[name] = value
+	}
+
+	path, _, _ := strings.Cut(profile.Render(request.URL), "?")
+
+	matched, err := document.FindOperation(request.Method, path)
+	if err != nil {
+		servers := document.DocumentServers()
+		if len(servers) == 0 {
+			return "", nil
+		}
+		return servers[0].Resolve(values)
+	}
+
+	return document.OperationServerURL(matched.Path, matched.Method, values)
+}
@@ -0,0 +1,50 @@
+package toolutils
+
+import (
+	"context"
+	"mcp-api-tester/storage"
+	"net/url"
+)
+
+// OperationOf match method and url like https://example.com/users/1 with operation in loaded document,
+// spec will be source of the document and operation will be like GET /users/{id},
+// path of url will be used as it is if no document is loaded or nothing matched,
+// operation will be templated path only like /users/{id} if method is empty,
+// error only happen when specName is given but not loaded
+func OperationOf(ctx context.Context, specName string, method string, rawURL string) (string, string, error) {
+	requestPath := rawURL
+	if parsedURL, err := url.Parse(rawURL); err == nil && parsedURL.Path != "" {
+		requestPath = parsedURL.Path
+	}
+
+	document, err := GetDocument(ctx, specName)
+	if err != nil {
+		if specName != "" {
+			return "", "", err
+		}
+		return "", operationKey(method, requestPath), nil
+	}
+
+	spec := document.Info().Source
+
+	if method == "" {
+		if path, ok := document.FindPath(requestPath); ok {
+			return spec, path, nil
+		}
+		return spec, requestPath, nil
+	}
+
+	matched, err := document.FindOperation(method, requestPath)
+	if err != nil {
+		return spec, operationKey(method, requestPath), nil
+	}
+
+	return spec, storage.OperationKey(matched.Method, matched.Path), nil
+}
+
+func operationKey(method string, path string) string {
+	if method == "" {
+		return path
+	}
+	return storage.OperationKey(method, path)
+}
@@ -0,0 +1,47 @@
+package toolutils
+
+import (
+	openapi "mcp-api-tester/openAPI"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_OperationOf(t *testing.T) {
+	const spec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+	specPath := filepath.Join(t.TempDir(), "users.yaml")
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	document, err := openapi.ReadFromPath(specPath)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	openapi.Documents.Store("", openapi.DefaultAlias, document)
+	defer openapi.Documents.RemoveSession("")
+
+	cases := map[string]string{
+		"GET http://localhost/users/42": "GET /users/{id}",
+		" http://localhost/users/42":    "/users/{id}",
+		" /orders/1":                    "/orders/1",
+	}
+
+	for input, expected := range cases {
+		method, rawURL, _ := strings.Cut(input, " ")
+		if _, operation, err := OperationOf(t.Context(), "", method, rawURL); err != nil || operation != expected {
+			t.Errorf("%q should be %q, got %q, error: %v", input, expected, operation, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package toolutils
+
+import (
+	"context"
+	"mcp-api-tester/coverage"
+	"mcp-api-tester/scenario"
+	"mcp-api-tester/storage"
+)
+
+// RecordScenario record every executed step into coverage of the session,
+// steps will also be saved into test history under runID if it is not empty
+func RecordScenario(ctx context.Context, runID string, result *scenario.Result) error {
+	tracker := coverage.Trackers.Get(SessionID(ctx))
+	for _, step := range result.Steps {
+		if step.Request == nil || step.Response == nil {
+			continue
+		}
+
+		tracker.Record(coverage.Request{
+			Method:      step.Request.Method,
+			URL:         step.Request.URL,
+			QueryParams: step.Request.QueryParams,
+			Headers:     step.Request.Headers,
+			Cookies:     step.Request.Cookies,
+			StatusCode:  step.Response.StatusCode,
+		})
+	}
+
+	if runID == "" {
+		return nil
+	}
+
+	for _, step := range result.Steps {
+		if step.Request == nil {
+			continue
+		}
+
+		if _, err := storage.Results.Append(stepRecord(ctx, runID, step)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stepRecord convert executed step into test history record
+func stepRecord(ctx context.Context, runID string, step scenario.StepResult) storage.Record {
+	spec, operation, _ := OperationOf(ctx, "", step.Request.Method, step.Request.URL)
+
+	record := storage.Record{
+		RunID:        runID,
+		Spec:         spec,
+		Operation:    operation,
+		URL:          step.Request.URL,
+		Request:      step.Request,
+		Passed:       step.Passed,
+		ErrorMessage: step.Error,
+	}
+
+	if step.Response != nil {
+		record.Response = &storage.ResponseSummary{
+			StatusCode: step.Response.StatusCode,
+			ElapsedMs:  step.Response.ElapsedMs,
+			Size:       step.Response.Size,
+			Timing:     step.Response.Timing,
+		}
+	}
+
+	for _, check := range step.Checks {
+		record.Assertions = append(record.Assertions, storage.AssertionResult{
+			Name:     check.Name,
+			Passed:   check.Passed,
+			Message:  check.Message,
+			Expected: check.Expected,
+			Actual:   check.Actual,
+		})
+	}
+
+	return record
+}
@@ -0,0 +1,56 @@
+package toolutils
+
+import (
+	"context"
+	"mcp-api-tester/auth"
+	"mcp-api-tester/environment"
+	netclient "mcp-api-tester/net/client"
+	openapi "mcp-api-tester/openAPI"
+	"net/url"
+)
+
+// ApplySecurity attach credentials required by the operation that request match,
+// credentials set by the session come first then credentials of active environment,
+// nil will be return if no document is loaded or no operation matched
+func ApplySecurity(ctx context.Context, specName string, request *netclient.AIRequest) *auth.Status {
+	document, err := GetDocument(ctx, specName)
+	if err != nil {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(request.URL)
+	if err != nil {
+		return nil
+	}
+
+	matched, err := document.FindOperation(request.Method, parsedURL.Path)
+	if err != nil {
+		return nil
+	}
+
+	requirements, err := document.OperationSecurity(matched.Path, matched.Method)
+	if err != nil {
+		return nil
+	}
+
+	return auth.Apply(ctx, request, document, requirements, CredentialLookup(ctx))
+}
+
+// CredentialLookup find credential of scheme set by the session or in active environment
+func CredentialLookup(ctx context.Context) auth.Lookup {
+	sessionID := SessionID(ctx)
+	profile := environment.Environments.Active(sessionID)
+
+	return func(scheme openapi.SecurityScheme) (auth.Credential, bool) {
+		if credential, ok := auth.Credentials.Get(sessionID, scheme.Name); ok {
+			return credential, true
+		}
+
+		if profile != nil {
+			credential, ok := profile.Credentials[scheme.Name]
+			return credential, ok
+		}
+
+		return auth.Credential{}, false
+	}
+}
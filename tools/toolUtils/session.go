@@ -0,0 +1,33 @@
+package toolutils
+
+import (
+	"context"
+	"fmt"
+	openapi "mcp-api-tester/openAPI"
+	"mcp-api-tester/tools"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SessionID return mcp session id of the client that calling the tool,
+// empty string will be return if ctx doesn't come from mcp server (like in test)
+func SessionID(ctx context.Context) string {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return ""
+	}
+
+	return session.SessionID()
+}
+
+// GetDocument return OpenAPI document that loaded by the calling session,
+// the active document will be return if alias is empty
+func GetDocument(ctx context.Context, alias string) (*openapi.OpenAPI, error) {
+	document, err := openapi.Documents.Get(SessionID(ctx), alias)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w, please use %q tool to read file first", err, tools.ReadOpenAPIDocument)
+	}
+
+	return document, nil
+}
@@ -16,6 +16,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mcp-api-tester/redact"
 	"reflect"
 
 	"github.com/invopop/jsonschema"
@@ -146,8 +147,12 @@ func ConvertTool[T any, R any](name, description string, toolHandler ToolHandler
 			}
 		}
 
-		// If there's an error, return nil result and the error
+		// If there's an error, return nil result and the error,
+		// message may contain request or response so it is redacted too
 		if handlerErr != nil {
+			if redact.Default.Enabled() {
+				return nil, errors.New(redact.Default.String(handlerErr.Error()))
+			}
 			return nil, handlerErr
 		}
 
@@ -182,21 +187,22 @@ func ConvertTool[T any, R any](name, description string, toolHandler ToolHandler
 			if str == "" {
 				return nil, nil
 			}
-			return mcp.NewToolResultText(str), nil
+			return mcp.NewToolResultText(redact.Default.String(str)), nil
 		}
 
 		if strPtr, ok := returnVal.(*string); ok {
 			if strPtr == nil || *strPtr == "" {
 				return nil, nil
 			}
-			return mcp.NewToolResultText(*strPtr), nil
+			return mcp.NewToolResultText(redact.Default.String(*strPtr)), nil
 		}
 
-		// Case 4: Any other type - marshal to JSON
+		// Case 4: Any other type - marshal to JSON, secrets in headers, cookies and fields are masked
 		jsonBytes, err := json.Marshal(returnVal)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal return value: %s", err)
 		}
+		jsonBytes = redact.Default.JSON(jsonBytes)
 
 		return mcp.NewToolResultText(string(jsonBytes)), nil
 	}
@@ -1,17 +1,101 @@
 package tools
 
+// CallNetHTTP is the tool that send real http request
+const CallNetHTTP = "CallNetHTTP"
+
+// GenerateExampleRequest is the tool name of generateExampleRequest
+const GenerateExampleRequest = "GenerateExampleRequest"
+
+// GenerateFuzzInput is the tool name of generateFuzzInput
+const GenerateFuzzInput = "GenerateFuzzInput"
+
+// GetAuthToken is the tool name of getAuthToken
+const GetAuthToken = "GetAuthToken"
+
+// GetCoverage is the tool name of getCoverage
+const GetCoverage = "GetCoverage"
+
+// GetOperationSecurity is the tool name of getOperationSecurity
+const GetOperationSecurity = "GetOperationSecurity"
+
+// GetTestHistory is the tool name of getTestHistory
+const GetTestHistory = "GetTestHistory"
+
+// GenerateTestReport is the tool name of generateTestReport
+const GenerateTestReport = "GenerateTestReport"
+
 // GetSingleAPIDetail is the tool that return OpenAPI operation detail
 const GetSingleAPIDetail = "GetSingleAPIDetail"
 
+// ImportPostmanCollection is the tool name of importPostmanCollection
+const ImportPostmanCollection = "ImportPostmanCollection"
+
 // ListAllAPIFromDocument is the tool name of listAllAPIFromDocument
 const ListAllAPIFromDocument = "ListAllAPIFromDocument"
 
+// ListLoadedDocuments is the tool name of listLoadedDocuments
+const ListLoadedDocuments = "ListLoadedDocuments"
+
+// ListPostmanRequests is the tool name of listPostmanRequests
+const ListPostmanRequests = "ListPostmanRequests"
+
+// ListServers is the tool name of listServers
+const ListServers = "ListServers"
+
+// LoadTest is the tool name of loadTest
+const LoadTest = "LoadTest"
+
 // ReadOpenAPIDocument is the tool name odf readOpenAPIDocument
 const ReadOpenAPIDocument = "ReadOpenAPIDocument"
 
+// RunPostmanCollection is the tool name of runPostmanCollection
+const RunPostmanCollection = "RunPostmanCollection"
+
+// RunScenario is the tool name of runScenario
+const RunScenario = "RunScenario"
+
+// SetAuthCredential is the tool name of setAuthCredential
+const SetAuthCredential = "SetAuthCredential"
+
+// StartMockServer is the tool name of startMockServer
+const StartMockServer = "StartMockServer"
+
+// StopMockServer is the tool name of stopMockServer
+const StopMockServer = "StopMockServer"
+
+// StoreTestResult is the tool name of storeTestResult
+const StoreTestResult = "StoreTestResult"
+
+// SwitchEnvironment is the tool name of switchEnvironment
+const SwitchEnvironment = "SwitchEnvironment"
+
+// ValidateResponseWithSchema is the tool name of validateResponseWithSchema
+const ValidateResponseWithSchema = "ValidateResponseWithSchema"
+
 // ToolNames has all tools' name in this project
 var ToolNames = map[string]string{
-	GetSingleAPIDetail:     GetSingleAPIDetail,
-	ListAllAPIFromDocument: ListAllAPIFromDocument,
-	ReadOpenAPIDocument:    ReadOpenAPIDocument,
+	CallNetHTTP:                CallNetHTTP,
+	GenerateExampleRequest:     GenerateExampleRequest,
+	GenerateFuzzInput:          GenerateFuzzInput,
+	GenerateTestReport:         GenerateTestReport,
+	GetAuthToken:               GetAuthToken,
+	GetCoverage:                GetCoverage,
+	GetOperationSecurity:       GetOperationSecurity,
+	GetTestHistory:             GetTestHistory,
+	GetSingleAPIDetail:         GetSingleAPIDetail,
+	ImportPostmanCollection:    ImportPostmanCollection,
+	ListAllAPIFromDocument:     ListAllAPIFromDocument,
+	ListLoadedDocuments:        ListLoadedDocuments,
+	ListPostmanRequests:        ListPostmanRequests,
+	ListServers:                ListServers,
+	LoadTest:                   LoadTest,
+	ReadOpenAPIDocument:        ReadOpenAPIDocument,
+	RunPostmanCollection:       RunPostmanCollection,
+	RunScenario:                RunScenario,
+	SetAuthCredential:          SetAuthCredential,
+	StartMockServer:            StartMockServer,
+	StopMockServer:             StopMockServer,
+	StoreTestResult:            StoreTestResult,
+	SwitchEnvironment:          SwitchEnvironment,
+	ValidateResponseWithSchema: ValidateResponseWithSchema,
 }
@@ -0,0 +1,74 @@
+// Package validateresponsewithschema will validate response body
+// against the response schema documented in OpenAPI file
+package validateresponsewithschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mcp-api-tester/tools"
+	toolutils "mcp-api-tester/tools/toolUtils"
+	"mcp-api-tester/validator"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Param provide param for validateResponseWithSchema
+// it will also be parse into tools description and mount to mcp server
+type Param struct {
+	URLPath     string `json:"urlPath" jsonschema:"required,description=The url path in OpenAPI file like /users/{id}"`
+	Method      string `json:"method" jsonschema:"required,description=Http method of the api,enum=get,enum=put,enum=post,enum=delete,enum=options,enum=head,enum=patch,enum=trace"`
+	StatusCode  int    `json:"statusCode" jsonschema:"required,description=Status code of the response like 200"`
+	ContentType string `json:"contentType,omitempty" jsonschema:"description=Content-Type header of the response\\, the first documented content type will be used if empty"`
+	Body        any    `json:"body,omitempty" jsonschema:"description=Response body can be raw string or json object"`
+	SpecName    string `json:"specName,omitempty" jsonschema:"description=Alias of the document given when read it\\, the last read document will be used if empty"`
+}
+
+func validateResponseWithSchema(ctx context.Context, args Param) (*validator.ResponseResult, error) {
+	document, err := toolutils.GetDocument(ctx, args.SpecName)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := document.GetOneAPIByPath(args.URLPath, args.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := bodyToBytes(args.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := validator.ValidateResponse(operation, args.StatusCode, args.ContentType, body)
+
+	return &result, nil
+}
+
+// bodyToBytes keep string body as it is and marshal other type into json
+func bodyToBytes(body any) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(b), nil
+	default:
+		bodyBytes, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("Convert body to json failed, error: %w", err)
+		}
+		return bodyBytes, nil
+	}
+}
+
+// ValidateResponseWithSchemaTool can register validateResponseWithSchema to MCP Server
+var ValidateResponseWithSchemaTool = toolutils.MustTool(
+	tools.ValidateResponseWithSchema,
+	fmt.Sprintf("%s will validate response status, content type and body against the schema in OpenAPI file and return violations located by json pointer, use it after %q", tools.ValidateResponseWithSchema, tools.CallNetHTTP),
+	validateResponseWithSchema,
+)
+
+// AddValidateResponseWithSchemaTool can register validateResponseWithSchema to MCP Server
+func AddValidateResponseWithSchemaTool(mcp *server.MCPServer) {
+	ValidateResponseWithSchemaTool.Register(mcp)
+}
@@ -0,0 +1,185 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Request is everything of a http request that can be validated before send
+type Request struct {
+	PathParams  map[string]string
+	QueryParams map[string]string
+	Headers     map[string]string
+	Cookies     map[string]string
+	ContentType string
+	Body        []byte
+}
+
+// RequestResult is the result of ValidateRequest
+type RequestResult struct {
+	Valid      bool        `json:"valid" yaml:"valid"`
+	Violations []Violation `json:"violations" yaml:"violations"`
+}
+
+// ValidateRequest validate path params, query params, headers, cookies and body
+// against parameters and requestBody of operation,
+// pointer of violation start with location like /query/page or /body/name
+func ValidateRequest(operation *v3high.Operation, parameters []*v3high.Parameter, request Request) RequestResult {
+	result := RequestResult{
+		Violations: []Violation{},
+	}
+
+	for _, parameter := range parameters {
+		value, ok := lookupParameter(parameter, request)
+		pointer := "/" + parameter.In + "/" + escapePointer(parameter.Name)
+
+		if !ok {
+			if parameter.In == "path" || (parameter.Required != nil && *parameter.Required) {
+				result.Violations = append(result.Violations, Violation{
+					Pointer: pointer,
+					Keyword: "required",
+					Message: fmt.Sprintf("required %s parameter %q is missing", parameter.In, parameter.Name),
+				})
+			}
+			continue
+		}
+
+		if value == "" && parameter.AllowEmptyValue {
+			continue
+		}
+
+		schema := parameterSchema(parameter)
+		result.Violations = append(result.Violations, Validate(schema, CoerceParameter(schema, value), pointer)...)
+	}
+
+	result.Violations = append(result.Violations, validateRequestBody(operation, request)...)
+	result.Valid = len(result.Violations) == 0
+
+	return result
+}
+
+// lookupParameter find value of parameter by its location, header is case insensitive
+func lookupParameter(parameter *v3high.Parameter, request Request) (string, bool) {
+	var values map[string]string
+
+	switch parameter.In {
+	case "path":
+		values = request.PathParams
+	case "query":
+		values = request.QueryParams
+	case "cookie":
+		values = request.Cookies
+	case "header":
+		for key, value := range request.Headers {
+			if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(parameter.Name) {
+				return value, true
+			}
+		}
+		return "", false
+	}
+
+	value, ok := values[parameter.Name]
+	return value, ok
+}
+
+// parameterSchema return schema of parameter, schema in content will be used if schema is empty
+func parameterSchema(parameter *v3high.Parameter) *base.Schema {
+	if parameter.Schema != nil {
+		return parameter.Schema.Schema()
+	}
+
+	if parameter.Content != nil && parameter.Content.Len() > 0 {
+		if mediaType := parameter.Content.First().Value(); mediaType.Schema != nil {
+			return mediaType.Schema.Schema()
+		}
+	}
+
+	return nil
+}
+
+// CoerceParameter convert string value of parameter into the type of schema,
+// value will be kept as string if it can't be converted so type violation will be reported
+func CoerceParameter(schema *base.Schema, value string) any {
+	if schema == nil {
+		return value
+	}
+
+	for _, schemaType := range schema.Type {
+		switch schemaType {
+		case "integer", "number":
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				return number
+			}
+		case "boolean":
+			if boolean, err := strconv.ParseBool(value); err == nil {
+				return boolean
+			}
+		case "array":
+			var itemSchema *base.Schema
+			if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+				itemSchema = schema.Items.A.Schema()
+			}
+
+			items := []any{}
+			if value != "" {
+				for _, item := range strings.Split(value, ",") {
+					items = append(items, CoerceParameter(itemSchema, item))
+				}
+			}
+			return items
+		}
+	}
+
+	return value
+}
+
+// validateRequestBody check requestBody is required, content type is documented and body match schema
+func validateRequestBody(operation *v3high.Operation, request Request) []Violation {
+	if operation == nil || operation.RequestBody == nil {
+		return nil
+	}
+
+	requestBody := operation.RequestBody
+	required := requestBody.Required != nil && *requestBody.Required
+
+	if len(request.Body) == 0 {
+		if required {
+			return []Violation{{
+				Pointer: "/body",
+				Keyword: "required",
+				Message: "request body is required",
+			}}
+		}
+		return nil
+	}
+
+	contentType := request.ContentType
+	if contentType == "" {
+		for key, value := range request.Headers {
+			if http.CanonicalHeaderKey(key) == "Content-Type" {
+				contentType = value
+			}
+		}
+	}
+
+	matchedContentType, mediaType := FindMediaType(requestBody.Content, contentType)
+	if mediaType == nil {
+		return []Violation{{
+			Pointer: "/body",
+			Keyword: "contentType",
+			Message: fmt.Sprintf("content type %q is not accepted by request body", contentType),
+		}}
+	}
+
+	violations := ValidateBody(mediaType, matchedContentType, request.Body)
+	for i := range violations {
+		violations[i].Pointer = "/body" + violations[i].Pointer
+	}
+
+	return violations
+}
@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+)
+
+const requestSpec = `openapi: 3.0.3
+info:
+  title: Request
+  version: 1.0.0
+paths:
+  /users/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: dryRun
+          in: query
+          schema:
+            type: boolean
+        - name: ids
+          in: query
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: session
+          in: cookie
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        "204":
+          description: updated
+`
+
+func Test_ValidateRequest(t *testing.T) {
+	document, err := libopenapi.NewDocument([]byte(requestSpec))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	model, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		t.Fatalf("%v", errs)
+	}
+
+	operation := model.Model.Paths.PathItems.GetOrZero("/users/{id}").Put
+
+	result := ValidateRequest(operation, operation.Parameters, Request{
+		PathParams:  map[string]string{"id": "1"},
+		QueryParams: map[string]string{"dryRun": "true", "ids": "1,2"},
+		Headers:     map[string]string{"x-request-id": "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		Cookies:     map[string]string{"session": "abc"},
+		ContentType: "application/json",
+		Body:        []byte(`{"name":"NewUser"}`),
+	})
+
+	if !result.Valid {
+		t.Errorf("Valid request should not have violations, got %v", result.Violations)
+	}
+
+	result = ValidateRequest(operation, operation.Parameters, Request{
+		PathParams:  map[string]string{"id": "abc"},
+		QueryParams: map[string]string{"dryRun": "maybe", "ids": "1,x"},
+		Headers:     map[string]string{"X-Request-Id": "not-uuid"},
+		ContentType: "application/json",
+		Body:        []byte(`{}`),
+	})
+
+	expected := map[string]string{
+		"/path/id":             "type",
+		"/query/dryRun":        "type",
+		"/query/ids/1":         "type",
+		"/header/X-Request-Id": "format",
+		"/cookie/session":      "required",
+		"/body/name":           "required",
+	}
+
+	got := make(map[string]string, len(result.Violations))
+	for _, violation := range result.Violations {
+		got[violation.Pointer] = violation.Keyword
+	}
+
+	for pointer, keyword := range expected {
+		if got[pointer] != keyword {
+			t.Errorf("Violation of %q should be %q, not %q", pointer, keyword, got[pointer])
+		}
+	}
+
+	result = ValidateRequest(operation, nil, Request{})
+	if result.Valid || result.Violations[0].Pointer != "/body" {
+		t.Errorf("Missing required body should be violation, got %v", result.Violations)
+	}
+}
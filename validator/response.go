@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ResponseResult is the result of ValidateResponse
+type ResponseResult struct {
+	Valid              bool        `json:"valid" yaml:"valid"`
+	MatchedStatus      string      `json:"matchedStatus,omitempty" yaml:"matchedStatus,omitempty"`           // like 200, 2XX or default
+	MatchedContentType string      `json:"matchedContentType,omitempty" yaml:"matchedContentType,omitempty"` // media type in document
+	Violations         []Violation `json:"violations" yaml:"violations"`
+}
+
+// FindResponse find response of status code, exact code first then range like 2XX then default
+func FindResponse(operation *v3high.Operation, statusCode int) (string, *v3high.Response) {
+	if operation == nil || operation.Responses == nil {
+		return "", nil
+	}
+
+	if operation.Responses.Codes != nil {
+		code := strconv.Itoa(statusCode)
+		rangeCode := fmt.Sprintf("%dXX", statusCode/100)
+
+		for _, candidate := range []string{code, rangeCode} {
+			for pair := operation.Responses.Codes.First(); pair != nil; pair = pair.Next() {
+				if strings.EqualFold(pair.Key(), candidate) {
+					return pair.Key(), pair.Value()
+				}
+			}
+		}
+	}
+
+	if operation.Responses.Default != nil {
+		return "default", operation.Responses.Default
+	}
+
+	return "", nil
+}
+
+// ValidateResponse validate status code, content type and body of response against operation
+func ValidateResponse(operation *v3high.Operation, statusCode int, contentType string, body []byte) ResponseResult {
+	result := ResponseResult{
+		Violations: []Violation{},
+	}
+
+	matchedStatus, response := FindResponse(operation, statusCode)
+	if response == nil {
+		result.Violations = append(result.Violations, Violation{
+			Keyword: "status",
+			Message: fmt.Sprintf("status code %d is not documented", statusCode),
+		})
+		return result
+	}
+
+	result.MatchedStatus = matchedStatus
+
+	// Response without content means no body is expected
+	if response.Content == nil || response.Content.Len() == 0 {
+		result.Valid = true
+		return result
+	}
+
+	matchedContentType, mediaType := FindMediaType(response.Content, contentType)
+	if mediaType == nil {
+		result.Violations = append(result.Violations, Violation{
+			Keyword: "contentType",
+			Message: fmt.Sprintf("content type %q is not documented for status %s", contentType, matchedStatus),
+		})
+		return result
+	}
+
+	result.MatchedContentType = matchedContentType
+	result.Violations = append(result.Violations, ValidateBody(mediaType, matchedContentType, body)...)
+	result.Valid = len(result.Violations) == 0
+
+	return result
+}
+
+// ValidateBody validate raw body with schema of media type, only json body will be validated by schema
+func ValidateBody(mediaType *v3high.MediaType, contentType string, body []byte) []Violation {
+	if mediaType == nil || mediaType.Schema == nil {
+		return nil
+	}
+
+	schema := mediaType.Schema.Schema()
+	if schema == nil {
+		return nil
+	}
+
+	if !IsJSONMediaType(contentType) {
+		// Plain text body can still be checked as string
+		if matchAnyType(schema.Type, string(body)) {
+			return Validate(schema, string(body), "")
+		}
+		return nil
+	}
+
+	value, ok := decodeJSON(body)
+	if !ok {
+		return []Violation{{
+			Keyword: "contentType",
+			Message: fmt.Sprintf("body is not valid json for content type %q", contentType),
+		}}
+	}
+
+	return Validate(schema, value, "")
+}
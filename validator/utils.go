@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"encoding/json"
+	"mime"
+	"sort"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// SortedKeys return keys of map in order, so violations are stable between runs
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsJSONMediaType check if media type is application/json or anything end with +json
+func IsJSONMediaType(mediaType string) bool {
+	mediaType = normalizeMediaType(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// normalizeMediaType remove parameters like charset and lower the media type
+func normalizeMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// FindMediaType find the best matched media type in content,
+// exact match first then wildcard like application/* and */*,
+// the first media type will be return if contentType is empty
+func FindMediaType(content *orderedmap.Map[string, *v3high.MediaType], contentType string) (string, *v3high.MediaType) {
+	if content == nil || content.Len() == 0 {
+		return "", nil
+	}
+
+	if contentType == "" {
+		first := content.First()
+		return first.Key(), first.Value()
+	}
+
+	mediaType := normalizeMediaType(contentType)
+	mainType, _, _ := strings.Cut(mediaType, "/")
+
+	candidates := []string{mediaType, mainType + "/*", "*/*"}
+
+	for _, candidate := range candidates {
+		for pair := content.First(); pair != nil; pair = pair.Next() {
+			if normalizeMediaType(pair.Key()) == candidate {
+				return pair.Key(), pair.Value()
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// decodeJSON decode body into json value, ok will be false if body is not json
+func decodeJSON(body []byte) (any, bool) {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,559 @@
+// Package validator will validate value against schema of OpenAPI document
+// and return violations located by json pointer
+// more doc https://swagger.io/docs/specification/v3_0/data-models/data-types/
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDepth stop validation of circular schema
+const maxDepth = 64
+
+// Violation is one place that value doesn't match the schema
+type Violation struct {
+	Pointer string `json:"pointer" yaml:"pointer"` // json pointer like /items/0/name
+	Keyword string `json:"keyword" yaml:"keyword"` // schema keyword that failed like required
+	Message string `json:"message" yaml:"message"`
+}
+
+// String format violation as "pointer: message"
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s", pointer, v.Message)
+}
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Validate check json decoded value against schema, pointer is where value located
+func Validate(schema *base.Schema, value any, pointer string) []Violation {
+	return validate(schema, value, pointer, 0)
+}
+
+func validate(schema *base.Schema, value any, pointer string, depth int) []Violation {
+	if schema == nil || depth > maxDepth {
+		return nil
+	}
+
+	if value == nil {
+		if isNullable(schema) {
+			return nil
+		}
+
+		// schema without type accept anything
+		if len(schema.Type) == 0 && len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 {
+			return nil
+		}
+
+		return []Violation{{Pointer: pointer, Keyword: "nullable", Message: "value should not be null"}}
+	}
+
+	var violations []Violation
+
+	if len(schema.Type) > 0 && !matchAnyType(schema.Type, value) {
+		return append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "type",
+			Message: fmt.Sprintf("expected type %s but got %s", strings.Join(schema.Type, " or "), TypeOf(value)),
+		})
+	}
+
+	violations = append(violations, validateEnum(schema, value, pointer)...)
+	violations = append(violations, validateComposition(schema, value, pointer, depth)...)
+
+	switch v := value.(type) {
+	case string:
+		violations = append(violations, validateString(schema, v, pointer)...)
+	case float64:
+		violations = append(violations, validateNumber(schema, v, pointer)...)
+	case map[string]any:
+		violations = append(violations, validateObject(schema, v, pointer, depth)...)
+	case []any:
+		violations = append(violations, validateArray(schema, v, pointer, depth)...)
+	}
+
+	return violations
+}
+
+// isNullable check nullable of 3.0 and "null" type of 3.1
+func isNullable(schema *base.Schema) bool {
+	if schema.Nullable != nil && *schema.Nullable {
+		return true
+	}
+
+	for _, schemaType := range schema.Type {
+		if schemaType == "null" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAnyType check if value is one of types
+func matchAnyType(types []string, value any) bool {
+	for _, schemaType := range types {
+		if matchType(schemaType, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchType(schemaType string, value any) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+// TypeOf return json schema type name of json decoded value
+func TypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func validateEnum(schema *base.Schema, value any, pointer string) []Violation {
+	var violations []Violation
+
+	if len(schema.Enum) > 0 {
+		allowed := make([]string, 0, len(schema.Enum))
+		found := false
+
+		for _, node := range schema.Enum {
+			enumValue := NodeToValue(node)
+			if reflect.DeepEqual(enumValue, value) {
+				found = true
+				break
+			}
+			allowed = append(allowed, fmt.Sprintf("%v", enumValue))
+		}
+
+		if !found {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "enum",
+				Message: fmt.Sprintf("value %v is not one of [%s]", value, strings.Join(allowed, ", ")),
+			})
+		}
+	}
+
+	if schema.Const != nil {
+		constValue := NodeToValue(schema.Const)
+		if !reflect.DeepEqual(constValue, value) {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "const",
+				Message: fmt.Sprintf("value %v should be %v", value, constValue),
+			})
+		}
+	}
+
+	return violations
+}
+
+func validateComposition(schema *base.Schema, value any, pointer string, depth int) []Violation {
+	var violations []Violation
+
+	for _, proxy := range schema.AllOf {
+		violations = append(violations, validate(proxy.Schema(), value, pointer, depth+1)...)
+	}
+
+	if len(schema.AnyOf) > 0 {
+		matched := false
+		for _, proxy := range schema.AnyOf {
+			if len(validate(proxy.Schema(), value, pointer, depth+1)) == 0 {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "anyOf",
+				Message: fmt.Sprintf("value doesn't match any of %d schemas", len(schema.AnyOf)),
+			})
+		}
+	}
+
+	if len(schema.OneOf) > 0 {
+		matched := 0
+		for _, proxy := range schema.OneOf {
+			if len(validate(proxy.Schema(), value, pointer, depth+1)) == 0 {
+				matched++
+			}
+		}
+
+		if matched != 1 {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "oneOf",
+				Message: fmt.Sprintf("value should match exactly one of %d schemas but matched %d", len(schema.OneOf), matched),
+			})
+		}
+	}
+
+	if schema.Not != nil && len(validate(schema.Not.Schema(), value, pointer, depth+1)) == 0 {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "not",
+			Message: "value should not match the schema of not",
+		})
+	}
+
+	return violations
+}
+
+func validateString(schema *base.Schema, value string, pointer string) []Violation {
+	var violations []Violation
+	length := int64(utf8.RuneCountInString(value))
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "minLength",
+			Message: fmt.Sprintf("length %d is shorter than minLength %d", length, *schema.MinLength),
+		})
+	}
+
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "maxLength",
+			Message: fmt.Sprintf("length %d is longer than maxLength %d", length, *schema.MaxLength),
+		})
+	}
+
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err == nil && !pattern.MatchString(value) {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "pattern",
+				Message: fmt.Sprintf("value %q doesn't match pattern %q", value, schema.Pattern),
+			})
+		}
+	}
+
+	if schema.Format != "" && !matchFormat(schema.Format, value) {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "format",
+			Message: fmt.Sprintf("value %q is not valid %s", value, schema.Format),
+		})
+	}
+
+	return violations
+}
+
+// matchFormat check string format, unknown format will always pass
+func matchFormat(format string, value string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, value)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", value)
+		if err != nil {
+			_, err = time.Parse(time.TimeOnly, value)
+		}
+		return err == nil
+	case "email":
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case "uuid":
+		return uuidRegexp.MatchString(value)
+	case "uri", "url":
+		parsedURL, err := url.Parse(value)
+		return err == nil && parsedURL.Scheme != ""
+	case "ipv4":
+		ip := net.ParseIP(value)
+		return ip != nil && ip.To4() != nil && strings.Contains(value, ".")
+	case "ipv6":
+		ip := net.ParseIP(value)
+		return ip != nil && strings.Contains(value, ":")
+	case "byte":
+		return isBase64(value)
+	}
+	return true
+}
+
+func isBase64(value string) bool {
+	if len(value)%4 != 0 {
+		return false
+	}
+	for _, r := range strings.TrimRight(value, "=") {
+		if !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '+' || r == '/') {
+			return false
+		}
+	}
+	return true
+}
+
+func validateNumber(schema *base.Schema, value float64, pointer string) []Violation {
+	var violations []Violation
+
+	if schema.Minimum != nil {
+		exclusive := schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsA() && schema.ExclusiveMinimum.A
+		if value < *schema.Minimum || (exclusive && value == *schema.Minimum) {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "minimum",
+				Message: fmt.Sprintf("value %v is less than minimum %v", value, *schema.Minimum),
+			})
+		}
+	}
+
+	if schema.Maximum != nil {
+		exclusive := schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsA() && schema.ExclusiveMaximum.A
+		if value > *schema.Maximum || (exclusive && value == *schema.Maximum) {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "maximum",
+				Message: fmt.Sprintf("value %v is greater than maximum %v", value, *schema.Maximum),
+			})
+		}
+	}
+
+	// exclusiveMinimum and exclusiveMaximum of 3.1 are numbers
+	if schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsB() && value <= schema.ExclusiveMinimum.B {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "exclusiveMinimum",
+			Message: fmt.Sprintf("value %v should be greater than %v", value, schema.ExclusiveMinimum.B),
+		})
+	}
+
+	if schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsB() && value >= schema.ExclusiveMaximum.B {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "exclusiveMaximum",
+			Message: fmt.Sprintf("value %v should be less than %v", value, schema.ExclusiveMaximum.B),
+		})
+	}
+
+	if schema.MultipleOf != nil && *schema.MultipleOf != 0 {
+		quotient := value / *schema.MultipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "multipleOf",
+				Message: fmt.Sprintf("value %v is not multiple of %v", value, *schema.MultipleOf),
+			})
+		}
+	}
+
+	switch schema.Format {
+	case "int32":
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "format",
+				Message: fmt.Sprintf("value %v is out of int32 range", value),
+			})
+		}
+	case "int64":
+		if value < math.MinInt64 || value > math.MaxInt64 {
+			violations = append(violations, Violation{
+				Pointer: pointer,
+				Keyword: "format",
+				Message: fmt.Sprintf("value %v is out of int64 range", value),
+			})
+		}
+	}
+
+	return violations
+}
+
+func validateObject(schema *base.Schema, value map[string]any, pointer string, depth int) []Violation {
+	var violations []Violation
+
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			violations = append(violations, Violation{
+				Pointer: pointer + "/" + escapePointer(name),
+				Keyword: "required",
+				Message: fmt.Sprintf("required property %q is missing", name),
+			})
+		}
+	}
+
+	propertyCount := int64(len(value))
+
+	if schema.MinProperties != nil && propertyCount < *schema.MinProperties {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "minProperties",
+			Message: fmt.Sprintf("object has %d properties, less than minProperties %d", propertyCount, *schema.MinProperties),
+		})
+	}
+
+	if schema.MaxProperties != nil && propertyCount > *schema.MaxProperties {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "maxProperties",
+			Message: fmt.Sprintf("object has %d properties, more than maxProperties %d", propertyCount, *schema.MaxProperties),
+		})
+	}
+
+	for _, name := range SortedKeys(value) {
+		propertyPointer := pointer + "/" + escapePointer(name)
+
+		if schema.Properties != nil {
+			if proxy := schema.Properties.GetOrZero(name); proxy != nil {
+				violations = append(violations, validate(proxy.Schema(), value[name], propertyPointer, depth+1)...)
+				continue
+			}
+		}
+
+		additional := schema.AdditionalProperties
+		if additional == nil {
+			continue
+		}
+
+		if additional.IsB() && !additional.B {
+			violations = append(violations, Violation{
+				Pointer: propertyPointer,
+				Keyword: "additionalProperties",
+				Message: fmt.Sprintf("property %q is not allowed", name),
+			})
+			continue
+		}
+
+		if additional.IsA() && additional.A != nil {
+			violations = append(violations, validate(additional.A.Schema(), value[name], propertyPointer, depth+1)...)
+		}
+	}
+
+	return violations
+}
+
+func validateArray(schema *base.Schema, value []any, pointer string, depth int) []Violation {
+	var violations []Violation
+	length := int64(len(value))
+
+	if schema.MinItems != nil && length < *schema.MinItems {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "minItems",
+			Message: fmt.Sprintf("array has %d items, less than minItems %d", length, *schema.MinItems),
+		})
+	}
+
+	if schema.MaxItems != nil && length > *schema.MaxItems {
+		violations = append(violations, Violation{
+			Pointer: pointer,
+			Keyword: "maxItems",
+			Message: fmt.Sprintf("array has %d items, more than maxItems %d", length, *schema.MaxItems),
+		})
+	}
+
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					violations = append(violations, Violation{
+						Pointer: pointer + "/" + strconv.Itoa(j),
+						Keyword: "uniqueItems",
+						Message: fmt.Sprintf("item %d is duplicate of item %d", j, i),
+					})
+				}
+			}
+		}
+	}
+
+	if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+		itemSchema := schema.Items.A.Schema()
+		for i, item := range value {
+			violations = append(violations, validate(itemSchema, item, pointer+"/"+strconv.Itoa(i), depth+1)...)
+		}
+	}
+
+	return violations
+}
+
+// escapePointer escape "~" and "/" in json pointer token
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// NodeToValue decode yaml node like enum, default and example into json decoded value
+func NodeToValue(node *yaml.Node) any {
+	if node == nil {
+		return nil
+	}
+
+	var decoded any
+	if err := node.Decode(&decoded); err != nil {
+		return node.Value
+	}
+
+	// yaml decode number into int, so convert it the same way as encoding/json
+	jsonBytes, err := json.Marshal(decoded)
+	if err != nil {
+		return decoded
+	}
+
+	var normalized any
+	if err := json.Unmarshal(jsonBytes, &normalized); err != nil {
+		return decoded
+	}
+
+	return normalized
+}
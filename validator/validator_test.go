@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+const validatorSpec = `openapi: 3.0.3
+info:
+  title: Validator
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/User"
+        4XX:
+          description: error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+                properties:
+                  title:
+                    type: string
+        "204":
+          description: no content
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, email, role]
+      additionalProperties: false
+      properties:
+        id:
+          type: integer
+          format: int32
+          minimum: 1
+        email:
+          type: string
+          format: email
+        role:
+          type: string
+          enum: [admin, member]
+        nickname:
+          type: string
+          nullable: true
+          maxLength: 5
+        createdAt:
+          type: string
+          format: date-time
+        tags:
+          type: array
+          uniqueItems: true
+          items:
+            type: string
+        contact:
+          oneOf:
+            - type: object
+              required: [phone]
+              properties:
+                phone:
+                  type: string
+            - type: object
+              required: [address]
+              properties:
+                address:
+                  type: string
+        score:
+          anyOf:
+            - type: integer
+            - type: string
+              pattern: "^[0-9]+$"
+        profile:
+          allOf:
+            - type: object
+              required: [bio]
+            - type: object
+              properties:
+                bio:
+                  type: string
+                  minLength: 3
+`
+
+func loadOperation(t *testing.T) *v3high.Operation {
+	t.Helper()
+
+	document, err := libopenapi.NewDocument([]byte(validatorSpec))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	model, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		t.Fatalf("%v", errs)
+	}
+
+	return model.Model.Paths.PathItems.GetOrZero("/users/{id}").Get
+}
+
+func Test_ValidateResponse(t *testing.T) {
+	operation := loadOperation(t)
+
+	valid := `{"id":1,"email":"a@example.com","role":"admin","nickname":null,"createdAt":"2024-01-02T03:04:05Z",
+"tags":["a","b"],"contact":{"phone":"123"},"score":"42","profile":{"bio":"hello"}}`
+
+	result := ValidateResponse(operation, 200, "application/json; charset=utf-8", []byte(valid))
+	if !result.Valid {
+		t.Errorf("Valid body should not have violations, got %v", result.Violations)
+	}
+
+	invalid := `{"id":1.5,"email":"not-email","role":"guest","nickname":"toolong","createdAt":"yesterday",
+"tags":["a","a"],"contact":{"phone":"1","address":"x"},"score":"abc","profile":{"bio":"hi"},"extra":true}`
+
+	result = ValidateResponse(operation, 200, "application/json", []byte(invalid))
+
+	expected := map[string]string{
+		"/id":          "type",
+		"/email":       "format",
+		"/role":        "enum",
+		"/nickname":    "maxLength",
+		"/createdAt":   "format",
+		"/tags/1":      "uniqueItems",
+		"/contact":     "oneOf",
+		"/score":       "anyOf",
+		"/profile/bio": "minLength",
+		"/extra":       "additionalProperties",
+	}
+
+	got := make(map[string]string, len(result.Violations))
+	for _, violation := range result.Violations {
+		got[violation.Pointer] = violation.Keyword
+	}
+
+	for pointer, keyword := range expected {
+		if got[pointer] != keyword {
+			t.Errorf("Violation of %q should be %q, not %q", pointer, keyword, got[pointer])
+		}
+	}
+
+	result = ValidateResponse(operation, 200, "application/json", []byte(`{"id":1}`))
+	if len(result.Violations) != 2 || result.Violations[0].Keyword != "required" {
+		t.Errorf("Missing email and role should be required violations, got %v", result.Violations)
+	}
+}
+
+func Test_ValidateResponseStatus(t *testing.T) {
+	operation := loadOperation(t)
+
+	result := ValidateResponse(operation, 404, "application/problem+json", []byte(`{}`))
+	if result.MatchedStatus != "4XX" || result.Valid {
+		t.Errorf("404 should match 4XX and miss title, got %+v", result)
+	}
+
+	result = ValidateResponse(operation, 204, "", nil)
+	if !result.Valid {
+		t.Errorf("Response without content should be valid, got %+v", result)
+	}
+
+	result = ValidateResponse(operation, 500, "application/json", nil)
+	if result.Valid || result.Violations[0].Keyword != "status" {
+		t.Errorf("Undocumented status should be violation, got %+v", result)
+	}
+
+	result = ValidateResponse(operation, 200, "text/html", []byte("<html>"))
+	if result.Valid || result.Violations[0].Keyword != "contentType" {
+		t.Errorf("Undocumented content type should be violation, got %+v", result)
+	}
+}